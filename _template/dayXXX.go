@@ -0,0 +1,26 @@
+package dayXXX
+
+import (
+	"github.com/kentquirk/aoc2021/input"
+	"github.com/kentquirk/aoc2021/registry"
+)
+
+func dayXXXa(lines []string) int {
+	return len(lines)
+}
+
+func dayXXXb(lines []string) int {
+	return len(lines)
+}
+
+func partA(in *input.Input) (interface{}, error) {
+	return dayXXXa(in.Lines()), nil
+}
+
+func partB(in *input.Input) (interface{}, error) {
+	return dayXXXb(in.Lines()), nil
+}
+
+func init() {
+	registry.Register(NNN, partA, partB)
+}
@@ -0,0 +1,49 @@
+package dayXXX
+
+import (
+	"testing"
+
+	"github.com/kentquirk/aoc2021/answers"
+	"github.com/kentquirk/aoc2021/bench"
+	"github.com/kentquirk/aoc2021/input"
+	"github.com/kentquirk/aoc2021/registry"
+)
+
+// TestSample checks both parts against the answers given in the puzzle
+// description; fill them in as you go. A part with no answer is skipped.
+func TestSample(t *testing.T) {
+	tests := []struct {
+		name   string
+		solver registry.Solver
+		want   string
+	}{
+		{"a", partA, ""},
+		{"b", partB, ""},
+	}
+	in, err := input.Load("inputsample.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.want == "" {
+				t.Skip("no expected answer yet")
+			}
+			got, err := tt.solver(in)
+			if err != nil {
+				t.Fatal(in.Explain(err))
+			}
+			if answers.Format(got) != tt.want {
+				t.Errorf("got %v, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func BenchmarkDayXXXa(b *testing.B) {
+	bench.Solver(b, partA, "input.txt")
+}
+
+func BenchmarkDayXXXb(b *testing.B) {
+	bench.Solver(b, partB, "input.txt")
+}
@@ -1,22 +0,0 @@
-package main
-
-import (
-	"fmt"
-	"io/ioutil"
-	"log"
-	"os"
-	"strings"
-)
-
-func main() {
-	f, err := os.Open("./input.txt")
-	if err != nil {
-		log.Fatal(err)
-	}
-	b, err := ioutil.ReadAll(f)
-	if err != nil {
-		log.Fatal(err)
-	}
-	lines := strings.Split(string(b), "\n")
-	fmt.Println(lines[0])
-}
@@ -1,7 +0,0 @@
-#! /usr/bin/env python3
-import itertools
-
-if __name__ == "__main__":
-    f = open("./input.txt")
-    lines = f.readlines()
-    data = [int(l) for l in lines]
@@ -0,0 +1,64 @@
+// Package answers reads the files that record the expected answers for
+// each day's inputs.
+package answers
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// File is the name of the answers file in each day's directory.
+const File = "answers.json"
+
+// Expected holds the expected answers for both parts of a single input.
+// A part with no recorded answer is left empty.
+type Expected struct {
+	A string `json:"a,omitempty"`
+	B string `json:"b,omitempty"`
+}
+
+// Part returns the expected answer for part "a" or "b", if there is one.
+func (e Expected) Part(part string) (string, bool) {
+	switch part {
+	case "a", "A":
+		return e.A, e.A != ""
+	case "b", "B":
+		return e.B, e.B != ""
+	}
+	return "", false
+}
+
+// Answers maps the name of an input file (like "inputsample.txt") to the
+// answers expected for it.
+type Answers map[string]Expected
+
+// Load reads the answers file from a day's directory.
+func Load(dir string) (Answers, error) {
+	b, err := os.ReadFile(filepath.Join(dir, File))
+	if err != nil {
+		return nil, err
+	}
+	var a Answers
+	if err := json.Unmarshal(b, &a); err != nil {
+		return nil, fmt.Errorf("%s: %v", filepath.Join(dir, File), err)
+	}
+	return a, nil
+}
+
+// Inputs returns the names of the input files that have answers, in order.
+func (a Answers) Inputs() []string {
+	var names []string
+	for name := range a {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Format converts an answer to the string form used in the answers file.
+func Format(answer interface{}) string {
+	return fmt.Sprint(answer)
+}
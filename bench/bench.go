@@ -0,0 +1,138 @@
+// Package bench measures how long the solvers take and how much they
+// allocate, and compares one set of measurements to another.
+package bench
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kentquirk/aoc2021/debug"
+	"github.com/kentquirk/aoc2021/input"
+	"github.com/kentquirk/aoc2021/registry"
+)
+
+// Result is the measurement of one part of one day against one input.
+type Result struct {
+	Day         int    `json:"day"`
+	Part        string `json:"part"`
+	Input       string `json:"input"`
+	N           int    `json:"n"`
+	NsPerOp     int64  `json:"ns_per_op"`
+	AllocsPerOp int64  `json:"allocs_per_op"`
+	BytesPerOp  int64  `json:"bytes_per_op"`
+}
+
+// Key identifies the measurement so it can be matched up with the same
+// measurement from another run.
+func (r Result) Key() string {
+	return fmt.Sprintf("%02d%s %s", r.Day, r.Part, r.Input)
+}
+
+// Solver benchmarks a solver from inside a Go benchmark. The input is
+// loaded before the timer starts, so only the solver itself is measured.
+func Solver(b *testing.B, solver registry.Solver, path string) {
+	in, err := input.Load(path)
+	if err != nil {
+		b.Fatal(err)
+	}
+	debug.Output = io.Discard
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := solver(in); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// Run measures a solver the same way "go test -bench" would. The solver
+// is expected to succeed; callers should check that before measuring it.
+func Run(day int, part string, solver registry.Solver, in *input.Input) Result {
+	br := testing.Benchmark(func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			solver(in)
+		}
+	})
+	return Result{
+		Day:         day,
+		Part:        part,
+		Input:       filepath.Base(in.Name),
+		N:           br.N,
+		NsPerOp:     br.NsPerOp(),
+		AllocsPerOp: br.AllocsPerOp(),
+		BytesPerOp:  br.AllocedBytesPerOp(),
+	}
+}
+
+// Save writes results to a JSON file.
+func Save(path string, results []Result) error {
+	b, err := json.MarshalIndent(results, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(b, '\n'), 0644)
+}
+
+// Load reads results that were written by Save.
+func Load(path string) ([]Result, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var results []Result
+	if err := json.Unmarshal(b, &results); err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	return results, nil
+}
+
+// Comparison pairs a result with the same measurement from an earlier run.
+type Comparison struct {
+	Old Result
+	New Result
+}
+
+func delta(old, new int64) float64 {
+	if old == 0 {
+		return 0
+	}
+	return float64(new-old) / float64(old)
+}
+
+// TimeDelta is the fractional change in time per operation; 0.1 means the
+// new run was 10% slower.
+func (c Comparison) TimeDelta() float64 {
+	return delta(c.Old.NsPerOp, c.New.NsPerOp)
+}
+
+// AllocsDelta is the fractional change in allocations per operation.
+func (c Comparison) AllocsDelta() float64 {
+	return delta(c.Old.AllocsPerOp, c.New.AllocsPerOp)
+}
+
+// IsRegression reports whether the new run got slower or allocates more
+// by more than the threshold (as a fraction).
+func (c Comparison) IsRegression(threshold float64) bool {
+	return c.TimeDelta() > threshold || c.AllocsDelta() > threshold
+}
+
+// Compare matches each new result with the old result for the same day,
+// part and input. Results with no earlier measurement are left out.
+func Compare(old []Result, new []Result) []Comparison {
+	byKey := make(map[string]Result)
+	for _, r := range old {
+		byKey[r.Key()] = r
+	}
+	var comparisons []Comparison
+	for _, r := range new {
+		if o, found := byKey[r.Key()]; found {
+			comparisons = append(comparisons, Comparison{Old: o, New: r})
+		}
+	}
+	return comparisons
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/kentquirk/aoc2021/bench"
+	"github.com/kentquirk/aoc2021/debug"
+)
+
+func formatBytes(n int64) string {
+	switch {
+	case n >= 1<<20:
+		return fmt.Sprintf("%.1f MB", float64(n)/(1<<20))
+	case n >= 1<<10:
+		return fmt.Sprintf("%.1f KB", float64(n)/(1<<10))
+	}
+	return fmt.Sprintf("%d B", n)
+}
+
+func printResults(results []bench.Result) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(w, "day\tpart\tinput\truns\ttime/op\tallocs/op\tbytes/op\t")
+	for _, r := range results {
+		fmt.Fprintf(w, "%02d\t%s\t%s\t%d\t%v\t%d\t%s\t\n",
+			r.Day, r.Part, r.Input, r.N, time.Duration(r.NsPerOp), r.AllocsPerOp, formatBytes(r.BytesPerOp))
+	}
+	w.Flush()
+}
+
+// printComparisons prints the changes from an earlier run and returns the
+// number of regressions.
+func printComparisons(comparisons []bench.Comparison, threshold float64) int {
+	regressions := 0
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(w, "day\tpart\told time/op\tnew time/op\tdelta\told allocs/op\tnew allocs/op\tdelta\t\t")
+	for _, c := range comparisons {
+		mark := ""
+		if c.IsRegression(threshold) {
+			mark = "REGRESSION"
+			regressions++
+		}
+		fmt.Fprintf(w, "%02d\t%s\t%v\t%v\t%+.1f%%\t%d\t%d\t%+.1f%%\t%s\t\n",
+			c.New.Day, c.New.Part,
+			time.Duration(c.Old.NsPerOp), time.Duration(c.New.NsPerOp), 100*c.TimeDelta(),
+			c.Old.AllocsPerOp, c.New.AllocsPerOp, 100*c.AllocsDelta(),
+			mark)
+	}
+	w.Flush()
+	return regressions
+}
+
+const benchUsage = "bench [-dir path] [-sample | -input name | -file path] [-save file] [-compare file] [-threshold f] <day|all> [a|b]"
+
+func benchCmd(args []string) error {
+	var inputs inputFlags
+	fs := flag.NewFlagSet("bench", flag.ExitOnError)
+	inputs.register(fs)
+	save := fs.String("save", "", "write the results to this JSON file")
+	compare := fs.String("compare", "", "compare the results to an earlier run saved in this JSON file")
+	threshold := fs.Float64("threshold", 0.1, "fractional slowdown or allocation increase that counts as a regression")
+	fs.Parse(args)
+	if fs.NArg() < 1 {
+		return fmt.Errorf("usage: aoc %s", benchUsage)
+	}
+
+	days, err := selectDays(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	if inputs.file != "" && len(days) > 1 {
+		return fmt.Errorf("-file can only be used with a single day")
+	}
+	parts, err := selectParts(fs.Args()[1:])
+	if err != nil {
+		return err
+	}
+
+	// the solutions' debug output would only get in the way here
+	debug.Output = io.Discard
+
+	var results []bench.Result
+	for _, d := range days {
+		in, err := inputs.load(d.Number)
+		if err != nil {
+			return err
+		}
+		for _, p := range parts {
+			solver, found := d.Part(p)
+			if !found {
+				continue
+			}
+			// make sure it works before spending time measuring it
+			if _, err := solve(solver, in); err != nil {
+				return fmt.Errorf("day %02d%s: %s", d.Number, p, in.Explain(err))
+			}
+			results = append(results, bench.Run(d.Number, p, solver, in))
+		}
+	}
+	printResults(results)
+
+	if *save != "" {
+		if err := bench.Save(*save, results); err != nil {
+			return err
+		}
+	}
+	if *compare != "" {
+		old, err := bench.Load(*compare)
+		if err != nil {
+			return err
+		}
+		fmt.Println()
+		if n := printComparisons(bench.Compare(old, results), *threshold); n != 0 {
+			return fmt.Errorf("%d regressions", n)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,237 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/kentquirk/aoc2021/answers"
+	"github.com/kentquirk/aoc2021/debug"
+	"github.com/kentquirk/aoc2021/input"
+	"github.com/kentquirk/aoc2021/registry"
+)
+
+// pythonScript is the Python version of a day's solution. It's run from
+// the day's directory with the name of the input file as its argument, and
+// prints its answers as lines that start with "a:" or "b:". Lines that
+// follow an answer without a prefix of their own continue it, for answers
+// (like day 13's) that are more than one line.
+const pythonScript = "main.py"
+
+// pythonStub is the main.py that setup.sh used to copy into every new day.
+// It doesn't solve anything (and can't even read most inputs), so a day that
+// still has it isn't run.
+const pythonStub = `#! /usr/bin/env python3
+import itertools
+
+if __name__ == "__main__":
+    f = open("./input.txt")
+    lines = f.readlines()
+    data = [int(l) for l in lines]`
+
+// errNotImplemented is reported for the stub, and for any other script that
+// runs but prints no answers
+var errNotImplemented = errors.New("the Python version is not implemented")
+
+// comparison is the outcome of running both versions of one part of a day
+// against one input
+type comparison struct {
+	Day    int
+	File   string
+	Part   string
+	Go     string
+	Python string
+	Error  error
+	In     *input.Input // for explaining parse errors
+}
+
+func (c comparison) OK() bool {
+	return c.Error == nil && c.Go == c.Python
+}
+
+func (c comparison) String() string {
+	name := strings.TrimSpace(fmt.Sprintf("day %02d%s %s", c.Day, c.Part, c.File))
+	switch {
+	case errors.Is(c.Error, errNotImplemented):
+		return fmt.Sprintf("TODO %s: %v", name, c.Error)
+	case c.Error != nil && c.In != nil:
+		return fmt.Sprintf("FAIL %s: %s", name, c.In.Explain(c.Error))
+	case c.Error != nil:
+		return fmt.Sprintf("FAIL %s: %v", name, c.Error)
+	case c.Go != c.Python:
+		return fmt.Sprintf("DIFF %s:\n  go:     %s\n  python: %s", name, formatAnswer(c.Go), formatAnswer(c.Python))
+	}
+	return fmt.Sprintf("ok   %s", name)
+}
+
+// parsePythonOutput collects the answers printed by a Python script
+func parsePythonOutput(out string) map[string]string {
+	result := make(map[string]string)
+	part := ""
+	for _, line := range strings.Split(strings.TrimRight(out, "\n"), "\n") {
+		line = strings.TrimRight(line, "\r")
+		if p := strings.ToLower(line); strings.HasPrefix(p, "a:") || strings.HasPrefix(p, "b:") {
+			part = p[:1]
+			result[part] = strings.TrimSpace(line[2:])
+			continue
+		}
+		if part != "" {
+			result[part] += "\n" + line
+		}
+	}
+	return result
+}
+
+// runPython runs a day's Python script on one input file
+func runPython(python string, dir string, file string, timeout time.Duration) (map[string]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, python, pythonScript, file)
+	cmd.Dir = dir
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() != nil {
+			return nil, fmt.Errorf("python: %v", ctx.Err())
+		}
+		msg := strings.TrimSpace(stderr.String())
+		if msg != "" {
+			lines := strings.Split(msg, "\n")
+			// the last line of a traceback is the one that says what went wrong
+			return nil, fmt.Errorf("python: %v: %s", err, lines[len(lines)-1])
+		}
+		return nil, fmt.Errorf("python: %v", err)
+	}
+	result := parsePythonOutput(stdout.String())
+	if len(result) == 0 {
+		return nil, errNotImplemented
+	}
+	return result, nil
+}
+
+// inputFiles returns the names of the input files in a day's directory
+func inputFiles(dir string) ([]string, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*input*.txt"))
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, p := range paths {
+		files = append(files, filepath.Base(p))
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// crossCheckDay runs both the Go and Python versions of a day on each of its
+// inputs and compares their answers. If only is not empty, just that input
+// file is checked. A day without a Python version has nothing to compare.
+func crossCheckDay(python string, root string, d *registry.Day, only string, timeout time.Duration) []comparison {
+	dir := dayDir(root, d.Number)
+	script, err := os.ReadFile(filepath.Join(dir, pythonScript))
+	if err != nil {
+		return nil
+	}
+	if strings.TrimSpace(string(script)) == pythonStub {
+		return []comparison{{Day: d.Number, Error: errNotImplemented}}
+	}
+	files, err := inputFiles(dir)
+	if err != nil {
+		return []comparison{{Day: d.Number, Error: err}}
+	}
+
+	var results []comparison
+	for _, file := range files {
+		if only != "" && file != only {
+			continue
+		}
+		in, err := input.Load(filepath.Join(dir, file))
+		if err != nil {
+			results = append(results, comparison{Day: d.Number, File: file, Error: err})
+			continue
+		}
+		py, err := runPython(python, dir, file, timeout)
+		if err != nil {
+			// the whole script failed, so there's nothing to say about each part
+			results = append(results, comparison{Day: d.Number, File: file, Error: err})
+			continue
+		}
+		for _, p := range []string{"a", "b"} {
+			solver, found := d.Part(p)
+			if !found {
+				continue
+			}
+			c := comparison{Day: d.Number, File: file, Part: p, In: in}
+			if answer, err := solve(solver, in); err != nil {
+				c.Error = err
+			} else if c.Python, found = py[p]; !found {
+				c.Error = fmt.Errorf("the Python version doesn't print an answer for part %s", p)
+			} else {
+				c.Go = answers.Format(answer)
+			}
+			results = append(results, c)
+		}
+	}
+	return results
+}
+
+const crossCheckUsage = "crosscheck [-dir path] [-sample] [-timeout d] [-v] [day|all]"
+
+func crossCheckCmd(args []string) error {
+	fs := flag.NewFlagSet("crosscheck", flag.ExitOnError)
+	root := fs.String("dir", ".", "directory containing the dayNN directories")
+	sample := fs.Bool("sample", false, "only check the sample inputs")
+	timeout := fs.Duration("timeout", time.Minute, "how long to let each Python run take")
+	verbose := fs.Bool("v", false, "list every comparison, not just the problems")
+	fs.Parse(args)
+
+	which := "all"
+	if fs.NArg() > 0 {
+		which = fs.Arg(0)
+	}
+	days, err := selectDays(which)
+	if err != nil {
+		return err
+	}
+	python, err := exec.LookPath("python3")
+	if err != nil {
+		return fmt.Errorf("crosscheck needs python3: %v", err)
+	}
+
+	only := ""
+	if *sample {
+		only = "inputsample.txt"
+	}
+	debug.Output = io.Discard
+
+	total, todo, failed := 0, 0, 0
+	for _, d := range days {
+		for _, c := range crossCheckDay(python, *root, d, only, *timeout) {
+			total++
+			switch {
+			case errors.Is(c.Error, errNotImplemented):
+				todo++
+			case !c.OK():
+				failed++
+			}
+			if *verbose || !c.OK() {
+				fmt.Println(c)
+			}
+		}
+	}
+	fmt.Printf("%d comparisons, %d failed, %d not implemented in Python\n", total, failed, todo)
+	if failed != 0 {
+		return fmt.Errorf("the Go and Python versions disagree")
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kentquirk/aoc2021/input"
+	"github.com/kentquirk/aoc2021/registry"
+)
+
+func TestParsePythonOutput(t *testing.T) {
+	tests := []struct {
+		name string
+		out  string
+		want map[string]string
+	}{
+		{"nothing", "", map[string]string{}},
+		{"both", "a: 7\nb: 5\n", map[string]string{"a": "7", "b": "5"}},
+		{"debug output first", "reading\nA:7\n", map[string]string{"a": "7"}},
+		{"multi-line", "a: 17\nb:\n#.#\n.#.\n", map[string]string{"a": "17", "b": "\n#.#\n.#."}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parsePythonOutput(tt.out); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parsePythonOutput() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCrossCheckDay(t *testing.T) {
+	python, err := exec.LookPath("python3")
+	if err != nil {
+		t.Skip("no python3")
+	}
+	count := func(in *input.Input) (interface{}, error) { return len(in.Lines()), nil }
+	day := &registry.Day{Number: 1, A: count, B: count}
+
+	tests := []struct {
+		name   string
+		script string
+		want   []string // the start of each result
+	}{
+		{"agree", `print("a:", 3); print("b:", 3)`, []string{"ok   day 01a", "ok   day 01b"}},
+		{"disagree", `print("a:", 3); print("b:", 4)`, []string{"ok   day 01a", "DIFF day 01b"}},
+		{"missing part", `print("a:", 3)`, []string{"ok   day 01a", "FAIL day 01b"}},
+		{"no answers", `pass`, []string{"TODO day 01 inputsample.txt"}},
+		{"stub", pythonStub, []string{"TODO day 01"}},
+		{"crash", `raise ValueError("oops")`, []string{"FAIL day 01 inputsample.txt: python: exit status 1: ValueError: oops"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			dir := dayDir(root, 1)
+			if err := os.Mkdir(dir, 0755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(filepath.Join(dir, "inputsample.txt"), []byte("1\n2\n3\n"), 0644); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(filepath.Join(dir, pythonScript), []byte(tt.script+"\n"), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			results := crossCheckDay(python, root, day, "", time.Minute)
+			if len(results) != len(tt.want) {
+				t.Fatalf("got %d results, want %d: %v", len(results), len(tt.want), results)
+			}
+			for i, r := range results {
+				if !strings.HasPrefix(r.String(), tt.want[i]) {
+					t.Errorf("result %d = %q, want it to start with %q", i, r, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestCrossCheckTimeout(t *testing.T) {
+	python, err := exec.LookPath("python3")
+	if err != nil {
+		t.Skip("no python3")
+	}
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, pythonScript), []byte("import time\ntime.sleep(10)\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	_, err = runPython(python, dir, "input.txt", 100*time.Millisecond)
+	if err == nil || errors.Is(err, errNotImplemented) {
+		t.Errorf("runPython() error = %v, want a timeout", err)
+	}
+}
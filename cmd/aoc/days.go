@@ -0,0 +1,26 @@
+package main
+
+// Each day registers its solvers when its package is initialized, so
+// adding a day to the runner is just a matter of importing it here.
+import (
+	_ "github.com/kentquirk/aoc2021/day01"
+	_ "github.com/kentquirk/aoc2021/day02"
+	_ "github.com/kentquirk/aoc2021/day03"
+	_ "github.com/kentquirk/aoc2021/day04"
+	_ "github.com/kentquirk/aoc2021/day05"
+	_ "github.com/kentquirk/aoc2021/day06"
+	_ "github.com/kentquirk/aoc2021/day07"
+	_ "github.com/kentquirk/aoc2021/day08"
+	_ "github.com/kentquirk/aoc2021/day09"
+	_ "github.com/kentquirk/aoc2021/day10"
+	_ "github.com/kentquirk/aoc2021/day11"
+	_ "github.com/kentquirk/aoc2021/day12"
+	_ "github.com/kentquirk/aoc2021/day13"
+	_ "github.com/kentquirk/aoc2021/day14"
+	_ "github.com/kentquirk/aoc2021/day15"
+	_ "github.com/kentquirk/aoc2021/day16"
+	_ "github.com/kentquirk/aoc2021/day17"
+	_ "github.com/kentquirk/aoc2021/day18"
+	_ "github.com/kentquirk/aoc2021/day19"
+	_ "github.com/kentquirk/aoc2021/day20"
+)
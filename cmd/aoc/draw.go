@@ -0,0 +1,61 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/kentquirk/aoc2021/registry"
+)
+
+const drawUsage = "draw [-dir path] [-sample | -input name | -file path] [-o file] [-format svg|png] <day>"
+
+// drawCmd draws a picture of a day's input, for the days that know how
+func drawCmd(args []string) error {
+	var inputs inputFlags
+	fs := flag.NewFlagSet("draw", flag.ExitOnError)
+	inputs.register(fs)
+	out := fs.String("o", "", "write the picture to this file instead of stdout")
+	format := fs.String("format", "", "svg or png; the default comes from the -o extension, or else svg")
+	fs.Parse(args)
+	if fs.NArg() != 1 {
+		return fmt.Errorf("usage: aoc %s", drawUsage)
+	}
+	n, err := parseDay(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	d, found := registry.Lookup(n)
+	if !found {
+		return fmt.Errorf("day %d has not been registered", n)
+	}
+	if d.Draw == nil {
+		return fmt.Errorf("day %d doesn't know how to draw itself", n)
+	}
+	if *format == "" {
+		*format = strings.TrimPrefix(filepath.Ext(*out), ".")
+		if *format == "" {
+			*format = "svg"
+		}
+	}
+	in, err := inputs.load(n)
+	if err != nil {
+		return err
+	}
+
+	if *out == "" {
+		return in.Wrap(d.Draw(in, *format, os.Stdout))
+	}
+	f, err := os.Create(*out)
+	if err != nil {
+		return err
+	}
+	if err := d.Draw(in, *format, f); err != nil {
+		f.Close()
+		os.Remove(*out)
+		return in.Wrap(err)
+	}
+	return f.Close()
+}
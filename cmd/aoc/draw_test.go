@@ -0,0 +1,53 @@
+package main
+
+import (
+	"bytes"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDraw(t *testing.T) {
+	dir := t.TempDir()
+	tests := []struct {
+		name    string
+		args    []string
+		file    string
+		wantErr bool
+	}{
+		{"svg", []string{"-sample", "-o", filepath.Join(dir, "day01.svg"), "1"}, "day01.svg", false},
+		{"png", []string{"-sample", "-o", filepath.Join(dir, "day01.png"), "1"}, "day01.png", false},
+		{"unknown format", []string{"-sample", "-format", "gif", "-o", filepath.Join(dir, "day01.gif"), "1"}, "", true},
+		{"can't draw", []string{"-sample", "-o", filepath.Join(dir, "day03.svg"), "3"}, "", true},
+		{"not a day", []string{"26"}, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := drawCmd(append([]string{"-dir", "../.."}, tt.args...))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("drawCmd() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			data, err := os.ReadFile(filepath.Join(dir, tt.file))
+			if err != nil {
+				t.Fatal(err)
+			}
+			switch filepath.Ext(tt.file) {
+			case ".svg":
+				if !bytes.HasPrefix(data, []byte("<svg")) {
+					t.Errorf("%s doesn't start with <svg", tt.file)
+				}
+			case ".png":
+				if _, err := png.Decode(bytes.NewReader(data)); err != nil {
+					t.Errorf("%s isn't a PNG: %v", tt.file, err)
+				}
+			}
+		})
+	}
+	if _, err := os.Stat(filepath.Join(dir, "day01.gif")); err == nil {
+		t.Errorf("a failed drawing left its file behind")
+	}
+}
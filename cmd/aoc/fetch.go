@@ -0,0 +1,96 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/kentquirk/aoc2021/fetch"
+)
+
+// siteFlags are the flags that say how to reach the puzzle site
+type siteFlags struct {
+	url         string
+	sessionFile string
+}
+
+func (f *siteFlags) register(fs *flag.FlagSet) {
+	url := os.Getenv("AOC_URL")
+	if url == "" {
+		url = fetch.DefaultURL
+	}
+	fs.StringVar(&f.url, "url", url, "base URL of the puzzle site (or set AOC_URL)")
+	fs.StringVar(&f.sessionFile, "session", ".aoc-session", "file holding the session token, relative to -dir (or set AOC_SESSION)")
+}
+
+// client creates a client for the site, using the session token from the
+// environment or from the session file
+func (f *siteFlags) client(root string) (*fetch.Client, error) {
+	session := os.Getenv("AOC_SESSION")
+	if session == "" {
+		path := f.sessionFile
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(root, path)
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("no session token: set AOC_SESSION or put it in %s", path)
+		}
+		session = strings.TrimSpace(string(data))
+	}
+	return fetch.NewClient(f.url, session), nil
+}
+
+// parseDay interprets a day number, which doesn't have to be registered yet
+func parseDay(arg string) (int, error) {
+	day, err := strconv.Atoi(arg)
+	if err != nil || day < 1 || day > 25 {
+		return 0, fmt.Errorf("day must be a number from 1 to 25, not %q", arg)
+	}
+	return day, nil
+}
+
+const fetchUsage = "fetch [-dir path] [-url url] [-session file] <day>..."
+
+func fetchCmd(args []string) error {
+	var site siteFlags
+	fs := flag.NewFlagSet("fetch", flag.ExitOnError)
+	root := fs.String("dir", ".", "directory containing the dayNN directories")
+	site.register(fs)
+	fs.Parse(args)
+	if fs.NArg() < 1 {
+		return fmt.Errorf("usage: aoc %s", fetchUsage)
+	}
+
+	var days []int
+	for _, arg := range fs.Args() {
+		day, err := parseDay(arg)
+		if err != nil {
+			return err
+		}
+		days = append(days, day)
+	}
+	client, err := site.client(*root)
+	if err != nil {
+		return err
+	}
+
+	cache := &fetch.Cache{Root: *root, Client: client}
+	for _, day := range days {
+		results, err := cache.Fetch(day)
+		for _, r := range results {
+			if r.Fetched {
+				fmt.Printf("fetched %s\n", r.Path)
+			} else {
+				fmt.Printf("cached  %s\n", r.Path)
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kentquirk/aoc2021/fetch/fetchtest"
+)
+
+func TestFetchCmd(t *testing.T) {
+	srv := fetchtest.NewServer("secret")
+	defer srv.Close()
+	srv.AddDay(21, fetchtest.Day{Input: "Player 1 starting position: 4\n", Sample: "sample\n"})
+
+	tests := []struct {
+		name    string
+		env     string
+		file    string
+		wantErr bool
+	}{
+		{"session from the environment", "secret", "", false},
+		{"session from a file", "", "secret\n", false},
+		{"no session", "", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			t.Setenv("AOC_SESSION", tt.env)
+			if tt.file != "" {
+				if err := os.WriteFile(filepath.Join(root, ".aoc-session"), []byte(tt.file), 0600); err != nil {
+					t.Fatal(err)
+				}
+			}
+			err := fetchCmd([]string{"-dir", root, "-url", srv.URL, "21"})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("fetchCmd() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			data, err := os.ReadFile(filepath.Join(root, "day21", "input.txt"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(data) != "Player 1 starting position: 4\n" {
+				t.Errorf("input.txt = %q", data)
+			}
+		})
+	}
+}
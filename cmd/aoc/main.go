@@ -0,0 +1,64 @@
+// Command aoc runs the Advent of Code 2021 solutions.
+//
+// Usage:
+//
+//	aoc run <day|all> [a|b]
+//	aoc verify [-sample] [day|all]
+//	aoc bench [-save file] [-compare file] <day|all> [a|b]
+//	aoc new <day>
+//	aoc fetch <day>...
+//	aoc submit <day> <a|b> [answer]
+//	aoc crosscheck [-sample] [day|all]
+//	aoc draw [-o file] <day>
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+)
+
+type command struct {
+	run   func(args []string) error
+	usage string
+}
+
+var commands = map[string]command{
+	"bench":      {benchCmd, benchUsage},
+	"crosscheck": {crossCheckCmd, crossCheckUsage},
+	"draw":       {drawCmd, drawUsage},
+	"fetch":      {fetchCmd, fetchUsage},
+	"new":        {newCmd, newUsage},
+	"run":        {runCmd, runUsage},
+	"submit":     {submitCmd, submitUsage},
+	"verify":     {verifyCmd, verifyUsage},
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage:")
+	var names []string
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(os.Stderr, "  aoc %s\n", commands[name].usage)
+	}
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+	cmd, found := commands[os.Args[1]]
+	if !found {
+		fmt.Fprintf(os.Stderr, "unknown command %q\n", os.Args[1])
+		usage()
+		os.Exit(2)
+	}
+	if err := cmd.run(os.Args[2:]); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
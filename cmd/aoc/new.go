@@ -0,0 +1,122 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const modulePath = "github.com/kentquirk/aoc2021"
+
+// templateDir holds the files that every new day starts from. In file names
+// and contents, XXX becomes the two-digit day and NNN the plain day number.
+const templateDir = "_template"
+
+// daysFile is where the runner imports each day so that it gets registered
+const daysFile = "cmd/aoc/days.go"
+
+// newDay creates the directory for a day from the template and adds the
+// day to the runner. It refuses to touch a day that already exists.
+func newDay(root string, day int) ([]string, error) {
+	dir := dayDir(root, day)
+	if _, err := os.Stat(dir); err == nil {
+		return nil, fmt.Errorf("%s already exists", dir)
+	}
+	days, err := addImport(root, day)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := os.ReadDir(filepath.Join(root, templateDir))
+	if err != nil {
+		return nil, err
+	}
+	nn := fmt.Sprintf("%02d", day)
+	replacer := strings.NewReplacer("XXX", nn, "NNN", strconv.Itoa(day))
+	files := make(map[string][]byte)
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(root, templateDir, e.Name()))
+		if err != nil {
+			return nil, err
+		}
+		name := filepath.Join(dir, replacer.Replace(e.Name()))
+		files[name] = []byte(replacer.Replace(string(data)))
+	}
+
+	// Mkdir fails if the directory appeared since we looked, so we still
+	// can't clobber anything
+	if err := os.Mkdir(dir, 0755); err != nil {
+		return nil, err
+	}
+	var created []string
+	for name, data := range files {
+		if err := os.WriteFile(name, data, 0644); err != nil {
+			return nil, err
+		}
+		created = append(created, name)
+	}
+	sort.Strings(created)
+
+	daysPath := filepath.Join(root, daysFile)
+	if err := os.WriteFile(daysPath, days, 0644); err != nil {
+		return nil, err
+	}
+	return append(created, daysPath), nil
+}
+
+// addImport returns the contents of the runner's days file with an import
+// for the day added in order
+func addImport(root string, day int) ([]byte, error) {
+	path := filepath.Join(root, daysFile)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	imp := fmt.Sprintf("\t_ %q", fmt.Sprintf("%s/day%02d", modulePath, day))
+	lines := strings.Split(string(data), "\n")
+	start := -1
+	for i, l := range lines {
+		switch {
+		case l == imp:
+			return nil, fmt.Errorf("day %d is already imported in %s", day, path)
+		case l == "import (":
+			start = i
+		case start != -1 && (l == ")" || l > imp):
+			lines = append(lines[:i], append([]string{imp}, lines[i:]...)...)
+			return format.Source([]byte(strings.Join(lines, "\n")))
+		}
+	}
+	return nil, fmt.Errorf("%s: can't find the import block", path)
+}
+
+const newUsage = "new [-dir path] <day>"
+
+func newCmd(args []string) error {
+	fs := flag.NewFlagSet("new", flag.ExitOnError)
+	root := fs.String("dir", ".", "directory containing the dayNN directories")
+	fs.Parse(args)
+	if fs.NArg() != 1 {
+		return fmt.Errorf("usage: aoc %s", newUsage)
+	}
+	day, err := parseDay(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	created, err := newDay(*root, day)
+	if err != nil {
+		return err
+	}
+	for _, name := range created {
+		fmt.Println(name)
+	}
+	return nil
+}
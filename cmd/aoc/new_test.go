@@ -0,0 +1,87 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// newRoot makes a copy of the template and a days file with days 1 and 3
+func newRoot(t *testing.T) string {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, templateDir), 0755); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"dayXXX.go", "dayXXX_test.go", "answers.json"} {
+		data, err := os.ReadFile(filepath.Join("../..", templateDir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(root, templateDir, name), data, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	days := "package main\n\nimport (\n" +
+		"\t_ \"github.com/kentquirk/aoc2021/day01\"\n" +
+		"\t_ \"github.com/kentquirk/aoc2021/day03\"\n)\n"
+	if err := os.MkdirAll(filepath.Join(root, "cmd/aoc"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, daysFile), []byte(days), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return root
+}
+
+func TestNewDay(t *testing.T) {
+	tests := []struct {
+		name    string
+		day     int
+		before  string
+		wantErr bool
+	}{
+		{"in the middle", 2, "day03", false},
+		{"at the end", 4, ")", false},
+		{"already imported", 3, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := newRoot(t)
+			_, err := newDay(root, tt.day)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("newDay() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				if _, err := os.Stat(dayDir(root, tt.day)); err == nil {
+					t.Errorf("newDay() created a directory for a day it refused")
+				}
+				return
+			}
+
+			pkg := fmt.Sprintf("day%02d", tt.day)
+			src, err := os.ReadFile(filepath.Join(root, pkg, pkg+".go"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := fmt.Sprintf("registry.Register(%d,", tt.day); !strings.Contains(string(src), want) {
+				t.Errorf("generated source doesn't contain %q:\n%s", want, src)
+			}
+
+			days, err := os.ReadFile(filepath.Join(root, daysFile))
+			if err != nil {
+				t.Fatal(err)
+			}
+			imp := strings.Index(string(days), pkg)
+			next := strings.Index(string(days), tt.before)
+			if imp == -1 || imp > next {
+				t.Errorf("import for day %d isn't before %s:\n%s", tt.day, tt.before, days)
+			}
+
+			if _, err := newDay(root, tt.day); err == nil {
+				t.Errorf("newDay() overwrote an existing day")
+			}
+		})
+	}
+}
@@ -0,0 +1,181 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kentquirk/aoc2021/debug"
+	"github.com/kentquirk/aoc2021/input"
+	"github.com/kentquirk/aoc2021/registry"
+)
+
+// selectDays interprets a day argument, which is either a day number or "all"
+func selectDays(arg string) ([]*registry.Day, error) {
+	var days []*registry.Day
+	if arg == "all" {
+		for _, n := range registry.Days() {
+			d, _ := registry.Lookup(n)
+			days = append(days, d)
+		}
+		return days, nil
+	}
+	n, err := strconv.Atoi(arg)
+	if err != nil {
+		return nil, fmt.Errorf("day must be a number or 'all', not %q", arg)
+	}
+	d, found := registry.Lookup(n)
+	if !found {
+		return nil, fmt.Errorf("day %d has not been registered", n)
+	}
+	return append(days, d), nil
+}
+
+// selectParts interprets the optional part argument; with no argument, both parts run
+func selectParts(args []string) ([]string, error) {
+	if len(args) == 0 {
+		return []string{"a", "b"}, nil
+	}
+	p := strings.ToLower(args[0])
+	if p != "a" && p != "b" {
+		return nil, fmt.Errorf("part must be 'a' or 'b', not %q", args[0])
+	}
+	return []string{p}, nil
+}
+
+func dayDir(root string, day int) string {
+	return filepath.Join(root, fmt.Sprintf("day%02d", day))
+}
+
+// solve runs a solver, turning a panic into an error so that one broken
+// day doesn't stop the others from running
+func solve(solver registry.Solver, in *input.Input) (answer interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%s: panic: %v", in.Name, r)
+		}
+	}()
+	answer, err = solver(in)
+	return answer, in.Wrap(err)
+}
+
+func formatAnswer(answer interface{}) string {
+	s := fmt.Sprint(answer)
+	// multi-line answers (like day 13's folded paper) start on their own line
+	if strings.Contains(s, "\n") {
+		return "\n" + strings.TrimRight(s, "\n")
+	}
+	return s
+}
+
+// inputFlags are the flags that choose which input each day is run against
+type inputFlags struct {
+	root    string
+	sample  bool
+	variant string
+	file    string
+}
+
+func (f *inputFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&f.root, "dir", ".", "directory containing the dayNN directories")
+	fs.BoolVar(&f.sample, "sample", false, "use the sample input (inputsample.txt)")
+	fs.StringVar(&f.variant, "input", "", "use a named input variant, like 'lq' for inputlq.txt or lqinput.txt")
+	fs.StringVar(&f.file, "file", "", "read the input from this file ('-' for stdin); only for a single day")
+}
+
+func (f *inputFlags) load(day int) (*input.Input, error) {
+	if f.file != "" {
+		return input.Load(f.file)
+	}
+	variant := f.variant
+	if f.sample {
+		variant = "sample"
+	}
+	return input.Variant(dayDir(f.root, day), variant)
+}
+
+// record is one answer in the -json output
+type record struct {
+	Day        int         `json:"day"`
+	Part       string      `json:"part"`
+	Answer     interface{} `json:"answer,omitempty"`
+	DurationNs int64       `json:"duration_ns"`
+	Error      string      `json:"error,omitempty"`
+}
+
+const runUsage = "run [-dir path] [-sample | -input name | -file path] [-json] [-q] <day|all> [a|b]"
+
+func runCmd(args []string) error {
+	var inputs inputFlags
+	fs := flag.NewFlagSet("run", flag.ExitOnError)
+	inputs.register(fs)
+	asJSON := fs.Bool("json", false, "write one JSON record per answer")
+	quiet := fs.Bool("q", false, "discard the solutions' debug output")
+	fs.Parse(args)
+	if fs.NArg() < 1 {
+		return fmt.Errorf("usage: aoc %s", runUsage)
+	}
+
+	days, err := selectDays(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	if inputs.file != "" && len(days) > 1 {
+		return fmt.Errorf("-file can only be used with a single day")
+	}
+	parts, err := selectParts(fs.Args()[1:])
+	if err != nil {
+		return err
+	}
+	if *quiet {
+		debug.Output = io.Discard
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	failures := 0
+	for _, d := range days {
+		in, err := inputs.load(d.Number)
+		if err != nil {
+			return err
+		}
+		for _, p := range parts {
+			solver, found := d.Part(p)
+			if !found {
+				continue
+			}
+			start := time.Now()
+			answer, err := solve(solver, in)
+			rec := record{
+				Day:        d.Number,
+				Part:       p,
+				Answer:     answer,
+				DurationNs: time.Since(start).Nanoseconds(),
+			}
+			if err != nil {
+				failures++
+				rec.Error = err.Error()
+			}
+
+			switch {
+			case *asJSON:
+				if err := enc.Encode(rec); err != nil {
+					return err
+				}
+			case err != nil:
+				fmt.Fprintf(os.Stderr, "Day %02d%s: %s\n", d.Number, p, in.Explain(err))
+			default:
+				fmt.Printf("Day %02d%s: %s\n", d.Number, p, formatAnswer(answer))
+			}
+		}
+	}
+	if failures != 0 {
+		return fmt.Errorf("%d parts failed", failures)
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/kentquirk/aoc2021/answers"
+	"github.com/kentquirk/aoc2021/debug"
+	"github.com/kentquirk/aoc2021/fetch"
+	"github.com/kentquirk/aoc2021/registry"
+)
+
+// computeAnswer runs a day's solver to get the answer to submit
+func computeAnswer(inputs *inputFlags, day int, part string) (string, error) {
+	d, found := registry.Lookup(day)
+	if !found {
+		return "", fmt.Errorf("day %d has not been registered", day)
+	}
+	solver, found := d.Part(part)
+	if !found {
+		return "", fmt.Errorf("day %d has no part %s", day, part)
+	}
+	in, err := inputs.load(day)
+	if err != nil {
+		return "", err
+	}
+	debug.Output = io.Discard
+	answer, err := solve(solver, in)
+	if err != nil {
+		return "", errors.New(in.Explain(err))
+	}
+	return answers.Format(answer), nil
+}
+
+const submitUsage = "submit [-dir path] [-url url] [-session file] [-input name | -file path] <day> <a|b> [answer]"
+
+func submitCmd(args []string) error {
+	var inputs inputFlags
+	var site siteFlags
+	fs := flag.NewFlagSet("submit", flag.ExitOnError)
+	inputs.register(fs)
+	site.register(fs)
+	fs.Parse(args)
+	if fs.NArg() < 2 || fs.NArg() > 3 {
+		return fmt.Errorf("usage: aoc %s", submitUsage)
+	}
+	if inputs.sample {
+		return fmt.Errorf("the sample's answers aren't worth submitting")
+	}
+
+	day, err := parseDay(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	parts, err := selectParts(fs.Args()[1:2])
+	if err != nil {
+		return err
+	}
+	part := parts[0]
+
+	answer := fs.Arg(2)
+	if answer == "" {
+		if answer, err = computeAnswer(&inputs, day, part); err != nil {
+			return err
+		}
+	}
+	answer = strings.TrimSpace(answer)
+	if strings.Contains(answer, "\n") {
+		// like day 13's folded paper, which has to be read by a person
+		return fmt.Errorf("the answer is more than one line; read it and submit what it says:\n%s", answer)
+	}
+
+	client, err := site.client(inputs.root)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Day %02d%s: submitting %s\n", day, part, answer)
+	r, err := client.SubmitLogged(dayDir(inputs.root, day), day, part, answer, time.Now())
+	if err != nil {
+		return err
+	}
+	fmt.Println(r.Message)
+	if r.Wait > 0 {
+		fmt.Printf("(wait %s before submitting again)\n", r.Wait)
+	}
+	if r.Verdict != fetch.Right && r.Verdict != fetch.Solved {
+		return fmt.Errorf("the answer was %s", r.Verdict)
+	}
+	return nil
+}
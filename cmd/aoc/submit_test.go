@@ -0,0 +1,49 @@
+package main
+
+import (
+	"os"
+	"testing"
+
+	"github.com/kentquirk/aoc2021/fetch"
+	"github.com/kentquirk/aoc2021/fetch/fetchtest"
+)
+
+func TestSubmitCmd(t *testing.T) {
+	srv := fetchtest.NewServer("secret")
+	defer srv.Close()
+	// day 1's sample answers
+	srv.AddDay(1, fetchtest.Day{A: "7", B: "5"})
+	root := t.TempDir()
+	if err := os.Mkdir(dayDir(root, 1), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("AOC_SESSION", "secret")
+
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr bool
+	}{
+		{"computed", []string{"-file", "../../day01/inputsample.txt", "1", "b"}, false},
+		{"wrong", []string{"1", "a", "6"}, true},
+		{"known wrong", []string{"1", "a", "6"}, true},
+		{"multi-line", []string{"1", "a", "#.\n.#"}, true},
+		{"sample", []string{"-sample", "1", "a"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := append([]string{"-dir", root, "-url", srv.URL}, tt.args...)
+			if err := submitCmd(args); (err != nil) != tt.wantErr {
+				t.Errorf("submitCmd() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	guesses, err := fetch.LoadGuesses(dayDir(root, 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(guesses) != 2 || guesses[0].Verdict != fetch.Right || guesses[1].Verdict != fetch.TooLow {
+		t.Errorf("guesses = %+v, want one right and one too low", guesses)
+	}
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"path/filepath"
+
+	"github.com/kentquirk/aoc2021/answers"
+	"github.com/kentquirk/aoc2021/debug"
+	"github.com/kentquirk/aoc2021/input"
+	"github.com/kentquirk/aoc2021/registry"
+)
+
+// check is the outcome of running one part of a day against one input
+type check struct {
+	Day   int
+	File  string
+	Part  string
+	Got   string
+	Want  string
+	Error error
+	In    *input.Input // for explaining parse errors
+}
+
+func (c check) OK() bool {
+	return c.Error == nil && c.Got == c.Want
+}
+
+func (c check) String() string {
+	name := fmt.Sprintf("day %02d%s %s", c.Day, c.Part, c.File)
+	switch {
+	case c.Error != nil && c.In != nil:
+		return fmt.Sprintf("FAIL %s: %s", name, c.In.Explain(c.Error))
+	case c.Error != nil:
+		return fmt.Sprintf("FAIL %s: %v", name, c.Error)
+	case c.Got != c.Want:
+		return fmt.Sprintf("FAIL %s:\n  got:  %s\n  want: %s", name, formatAnswer(c.Got), formatAnswer(c.Want))
+	}
+	return fmt.Sprintf("ok   %s", name)
+}
+
+// verifyDay runs the inputs listed in a day's answers file and compares
+// the results to the recorded answers. If only is not empty, just that
+// input file is checked.
+func verifyDay(root string, d *registry.Day, only string) []check {
+	dir := dayDir(root, d.Number)
+	expected, err := answers.Load(dir)
+	if err != nil {
+		return []check{{Day: d.Number, File: answers.File, Error: err}}
+	}
+
+	var checks []check
+	for _, file := range expected.Inputs() {
+		if only != "" && file != only {
+			continue
+		}
+		in, err := input.Load(filepath.Join(dir, file))
+		if err != nil {
+			checks = append(checks, check{Day: d.Number, File: file, Error: err})
+			continue
+		}
+		for _, p := range []string{"a", "b"} {
+			want, found := expected[file].Part(p)
+			if !found {
+				continue
+			}
+			c := check{Day: d.Number, File: file, Part: p, Want: want, In: in}
+			solver, found := d.Part(p)
+			if !found {
+				c.Error = fmt.Errorf("part %s is not registered", p)
+			} else if answer, err := solve(solver, in); err != nil {
+				c.Error = err
+			} else {
+				c.Got = answers.Format(answer)
+			}
+			checks = append(checks, c)
+		}
+	}
+	return checks
+}
+
+const verifyUsage = "verify [-dir path] [-sample] [-v] [day|all]"
+
+func verifyCmd(args []string) error {
+	fs := flag.NewFlagSet("verify", flag.ExitOnError)
+	root := fs.String("dir", ".", "directory containing the dayNN directories")
+	sample := fs.Bool("sample", false, "only check the sample inputs")
+	verbose := fs.Bool("v", false, "list every check, not just the failures")
+	fs.Parse(args)
+
+	which := "all"
+	if fs.NArg() > 0 {
+		which = fs.Arg(0)
+	}
+	days, err := selectDays(which)
+	if err != nil {
+		return err
+	}
+
+	only := ""
+	if *sample {
+		only = "inputsample.txt"
+	}
+
+	// the solutions' debug output would only get in the way here
+	debug.Output = io.Discard
+
+	total, failed := 0, 0
+	for _, d := range days {
+		for _, c := range verifyDay(*root, d, only) {
+			total++
+			if !c.OK() {
+				failed++
+			}
+			if *verbose || !c.OK() {
+				fmt.Println(c)
+			}
+		}
+	}
+	fmt.Printf("%d checks, %d failed\n", total, failed)
+	if failed != 0 {
+		return fmt.Errorf("verification failed")
+	}
+	return nil
+}
@@ -0,0 +1,25 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/kentquirk/aoc2021/registry"
+)
+
+// TestAnswers checks every registered day against the recorded answers
+// for its sample input, so that a refactor that breaks a solution shows up
+// in go test. Some of the full inputs take minutes, so those are left to
+// "aoc verify".
+func TestAnswers(t *testing.T) {
+	for _, n := range registry.Days() {
+		d, _ := registry.Lookup(n)
+		t.Run(fmt.Sprintf("day%02d", n), func(t *testing.T) {
+			for _, c := range verifyDay("../..", d, "inputsample.txt") {
+				if !c.OK() {
+					t.Error(c)
+				}
+			}
+		})
+	}
+}
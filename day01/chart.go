@@ -0,0 +1,168 @@
+package day01
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+)
+
+// Chart draws a depth profile: the depths as a line going across the page,
+// deeper toward the bottom, with a band behind each stretch of readings
+// where the sum of a window increased.
+type Chart struct {
+	Width  int // in pixels
+	Height int
+	Window int
+}
+
+// the margin around the line, in pixels
+const margin = 10
+
+var (
+	background = color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}
+	highlight  = color.RGBA{R: 0xff, G: 0xd7, B: 0x8c, A: 0xff}
+	stroke     = color.RGBA{R: 0x1f, G: 0x4e, B: 0x96, A: 0xff}
+)
+
+// Increases returns the stretches of readings where a window's sum went up,
+// as pairs of indexes into depths, inclusive. A stretch runs from the reading
+// that left the window to the one that came in; stretches that touch are
+// merged.
+func (c Chart) Increases(depths []int) [][2]int {
+	var result [][2]int
+	for i := c.Window; i < len(depths); i++ {
+		if depths[i] <= depths[i-c.Window] {
+			continue
+		}
+		if n := len(result); n > 0 && result[n-1][1] >= i-c.Window {
+			result[n-1][1] = i
+			continue
+		}
+		result = append(result, [2]int{i - c.Window, i})
+	}
+	return result
+}
+
+// x and y map a reading to where it goes on the chart
+func (c Chart) x(i int, n int) float64 {
+	if n < 2 {
+		return float64(c.Width) / 2
+	}
+	return margin + float64(i)*float64(c.Width-2*margin)/float64(n-1)
+}
+
+func (c Chart) y(depth int, shallowest int, deepest int) float64 {
+	if deepest == shallowest {
+		return float64(c.Height) / 2
+	}
+	return margin + float64(depth-shallowest)*float64(c.Height-2*margin)/float64(deepest-shallowest)
+}
+
+func extent(depths []int) (int, int) {
+	if len(depths) == 0 {
+		return 0, 0
+	}
+	lo, hi := depths[0], depths[0]
+	for _, d := range depths {
+		if d < lo {
+			lo = d
+		}
+		if d > hi {
+			hi = d
+		}
+	}
+	return lo, hi
+}
+
+func (c Chart) check() error {
+	if c.Width <= 2*margin || c.Height <= 2*margin {
+		return fmt.Errorf("a chart must be more than %d pixels each way, not %dx%d", 2*margin, c.Width, c.Height)
+	}
+	if c.Window < 1 {
+		return fmt.Errorf("the window must hold at least one reading, not %d", c.Window)
+	}
+	return nil
+}
+
+func hex(c color.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
+
+// SVG writes the chart as an SVG document.
+func (c Chart) SVG(w io.Writer, depths []int) error {
+	if err := c.check(); err != nil {
+		return err
+	}
+	n := len(depths)
+	lo, hi := extent(depths)
+	fmt.Fprintf(w, "<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"%d\" height=\"%d\" viewBox=\"0 0 %d %d\">\n",
+		c.Width, c.Height, c.Width, c.Height)
+	fmt.Fprintf(w, "<title>%d depths from %d to %d, window %d</title>\n", n, lo, hi, c.Window)
+	fmt.Fprintf(w, "<rect width=\"100%%\" height=\"100%%\" fill=\"%s\"/>\n", hex(background))
+	for _, r := range c.Increases(depths) {
+		x0, x1 := c.x(r[0], n), c.x(r[1], n)
+		fmt.Fprintf(w, "<rect class=\"increase\" x=\"%.1f\" y=\"0\" width=\"%.1f\" height=\"%d\" fill=\"%s\"/>\n",
+			x0, x1-x0, c.Height, hex(highlight))
+	}
+	fmt.Fprintf(w, "<polyline fill=\"none\" stroke=\"%s\" stroke-width=\"1\" points=\"", hex(stroke))
+	for i, d := range depths {
+		if i > 0 {
+			fmt.Fprint(w, " ")
+		}
+		fmt.Fprintf(w, "%.1f,%.1f", c.x(i, n), c.y(d, lo, hi))
+	}
+	_, err := fmt.Fprint(w, "\"/>\n</svg>\n")
+	return err
+}
+
+// Image draws the chart.
+func (c Chart) Image(depths []int) (*image.RGBA, error) {
+	if err := c.check(); err != nil {
+		return nil, err
+	}
+	img := image.NewRGBA(image.Rect(0, 0, c.Width, c.Height))
+	draw.Draw(img, img.Bounds(), image.NewUniform(background), image.Point{}, draw.Src)
+	n := len(depths)
+	lo, hi := extent(depths)
+	for _, r := range c.Increases(depths) {
+		band := image.Rect(int(c.x(r[0], n)), 0, int(c.x(r[1], n))+1, c.Height)
+		draw.Draw(img, band, image.NewUniform(highlight), image.Point{}, draw.Src)
+	}
+	for i := 1; i < n; i++ {
+		line(img, c.x(i-1, n), c.y(depths[i-1], lo, hi), c.x(i, n), c.y(depths[i], lo, hi))
+	}
+	if n == 1 {
+		img.Set(int(c.x(0, n)), int(c.y(depths[0], lo, hi)), stroke)
+	}
+	return img, nil
+}
+
+// line draws from (x0,y0) to (x1,y1), one pixel at a time
+func line(img *image.RGBA, x0, y0, x1, y1 float64) {
+	steps := int(x1 - x0)
+	if dy := int(y1 - y0); dy > steps || -dy > steps {
+		steps = dy
+		if steps < 0 {
+			steps = -steps
+		}
+	}
+	if steps < 1 {
+		steps = 1
+	}
+	for s := 0; s <= steps; s++ {
+		t := float64(s) / float64(steps)
+		img.Set(int(x0+t*(x1-x0)+0.5), int(y0+t*(y1-y0)+0.5), stroke)
+	}
+}
+
+// PNG writes the chart as a PNG image.
+func (c Chart) PNG(w io.Writer, depths []int) error {
+	img, err := c.Image(depths)
+	if err != nil {
+		return err
+	}
+	return png.Encode(w, img)
+}
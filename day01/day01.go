@@ -0,0 +1,48 @@
+package day01
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/kentquirk/aoc2021/input"
+	"github.com/kentquirk/aoc2021/registry"
+)
+
+// countIncreases counts the times the sum of a window of depths increased
+func countIncreases(in *input.Input, window int) (interface{}, error) {
+	sweep, err := Analyze(strings.NewReader(in.Text), window)
+	if err != nil {
+		return nil, err
+	}
+	return sweep.Increases, nil
+}
+
+func partA(in *input.Input) (interface{}, error) {
+	return countIncreases(in, 1)
+}
+
+func partB(in *input.Input) (interface{}, error) {
+	return countIncreases(in, 3)
+}
+
+// drawDepths charts the depths, highlighting the windows of part B
+func drawDepths(in *input.Input, format string, w io.Writer) error {
+	depths, err := ReadDepths(strings.NewReader(in.Text))
+	if err != nil {
+		return err
+	}
+	chart := Chart{Width: 1200, Height: 400, Window: 3}
+	switch format {
+	case "svg":
+		return chart.SVG(w, depths)
+	case "png":
+		return chart.PNG(w, depths)
+	}
+	return fmt.Errorf("day 1 can be drawn as svg or png, not %q", format)
+}
+
+func init() {
+	registry.Register(1, partA, partB)
+	registry.RegisterDrawer(1, drawDepths)
+}
@@ -0,0 +1,124 @@
+package day01
+
+import (
+	"bytes"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/kentquirk/aoc2021/bench"
+)
+
+const sample = "199\n200\n208\n210\n200\n207\n240\n269\n260\n263\n"
+
+func TestAnalyze(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		window  int
+		want    Sweep
+		wantErr bool
+	}{
+		{"part a", sample, 1, Sweep{Window: 1, Readings: 10, Increases: 7, Decreases: 2, LongestRun: 3}, false},
+		{"part b", sample, 3, Sweep{Window: 3, Readings: 10, Increases: 5, Decreases: 1, Flat: 1, LongestRun: 4}, false},
+		{"one window", sample, 10, Sweep{Window: 10, Readings: 10}, false},
+		{"too few", sample, 11, Sweep{Window: 11, Readings: 10}, false},
+		{"empty", "", 2, Sweep{Window: 2}, false},
+		{"flat", "5\n5\n5\n", 1, Sweep{Window: 1, Readings: 3, Flat: 2}, false},
+		{"crlf", "1\r\n2\r\n", 1, Sweep{Window: 1, Readings: 2, Increases: 1, LongestRun: 1}, false},
+		{"no window", sample, 0, Sweep{}, true},
+		{"bad depth", "1\n2\nthree\n", 1, Sweep{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Analyze(strings.NewReader(tt.text), tt.window)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Analyze() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("Analyze() = %+v, want %+v", got, tt.want)
+			}
+			if !tt.wantErr && got.Comparisons() != got.Increases+got.Decreases+got.Flat {
+				t.Errorf("Comparisons() = %d", got.Comparisons())
+			}
+		})
+	}
+}
+
+func TestChart(t *testing.T) {
+	depths, err := ReadDepths(strings.NewReader(sample))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name   string
+		window int
+		want   [][2]int
+		rects  int
+	}{
+		{"part a", 1, [][2]int{{0, 3}, {4, 7}, {8, 9}}, 3},
+		{"part b", 3, [][2]int{{0, 9}}, 1},
+		{"too wide", 10, nil, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := Chart{Width: 200, Height: 100, Window: tt.window}
+			got := c.Increases(depths)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Increases() = %v, want %v", got, tt.want)
+			}
+			var svg bytes.Buffer
+			if err := c.SVG(&svg, depths); err != nil {
+				t.Fatal(err)
+			}
+			if n := strings.Count(svg.String(), `class="increase"`); n != tt.rects {
+				t.Errorf("SVG has %d highlights, want %d", n, tt.rects)
+			}
+			img, err := c.Image(depths)
+			if err != nil {
+				t.Fatal(err)
+			}
+			// the top corners are in a band if the first and last readings are
+			if got := img.RGBAAt(int(c.x(0, len(depths))), 0) == highlight; got != (len(tt.want) > 0) {
+				t.Errorf("top left highlighted = %v", got)
+			}
+			// the shallowest reading is at the top, and the deepest at the bottom
+			if img.RGBAAt(margin, margin) != stroke || img.RGBAAt(200-margin-1, 100-margin-1) == stroke {
+				t.Errorf("the line is in the wrong place")
+			}
+		})
+	}
+	if err := (Chart{Width: 5, Height: 100, Window: 1}).SVG(&bytes.Buffer{}, depths); err == nil {
+		t.Errorf("drew a chart too small to see")
+	}
+}
+
+func BenchmarkDay01a(b *testing.B) {
+	bench.Solver(b, partA, "input.txt")
+}
+
+func BenchmarkDay01b(b *testing.B) {
+	bench.Solver(b, partB, "input.txt")
+}
+
+// a long instrument log, to show that the window size doesn't matter
+func BenchmarkAnalyze(b *testing.B) {
+	var buf bytes.Buffer
+	depth := 0
+	for i := 0; i < 1000000; i++ {
+		depth += i%7 - 3
+		buf.WriteString(strconv.Itoa(depth))
+		buf.WriteByte('\n')
+	}
+	log := buf.Bytes()
+	for _, window := range []int{1, 3, 1000} {
+		b.Run(strconv.Itoa(window), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := Analyze(bytes.NewReader(log), window); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -1,7 +1,11 @@
 #! /usr/bin/env python3
-import itertools
+import sys
 
 if __name__ == "__main__":
-    f = open("./input.txt")
-    lines = f.readlines()
-    data = [int(l) for l in lines]
+    filename = sys.argv[1] if len(sys.argv) > 1 else "input.txt"
+    with open(filename) as f:
+        data = [int(l) for l in f.read().split()]
+    print("a:", sum(1 for x, y in zip(data, data[1:]) if y > x))
+    # comparing windows that share two elements is the same as comparing
+    # the elements that differ
+    print("b:", sum(1 for x, y in zip(data, data[3:]) if y > x))
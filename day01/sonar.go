@@ -0,0 +1,94 @@
+package day01
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/kentquirk/aoc2021/input"
+)
+
+// Sweep summarizes a sonar sweep: how the sum of a sliding window of depth
+// readings changes as the window moves along one reading at a time.
+type Sweep struct {
+	Window    int // the number of readings in each sum
+	Readings  int
+	Increases int
+	Decreases int
+	Flat      int
+	// LongestRun is the most times in a row that the sum increased.
+	LongestRun int
+}
+
+// Comparisons returns the number of times one window's sum was compared
+// with the one before it.
+func (s Sweep) Comparisons() int {
+	return s.Increases + s.Decreases + s.Flat
+}
+
+// Analyze reads depths, one per line, and summarizes the sums of each
+// window of that many readings. Two neighboring windows share all but one
+// reading at each end, so comparing their sums is the same as comparing the
+// reading coming in with the one going out; only the last window's worth
+// of readings is kept, however long the input is.
+func Analyze(r io.Reader, window int) (Sweep, error) {
+	if window < 1 {
+		return Sweep{}, fmt.Errorf("the window must hold at least one reading, not %d", window)
+	}
+	s := Sweep{Window: window}
+	// ring holds the last window readings; next is the oldest of them
+	ring := make([]int, window)
+	next := 0
+	run := 0
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		depth, err := parseDepth(scanner.Text(), s.Readings+1)
+		if err != nil {
+			return s, err
+		}
+		if s.Readings >= window {
+			switch leaving := ring[next]; {
+			case depth > leaving:
+				s.Increases++
+				run++
+				if run > s.LongestRun {
+					s.LongestRun = run
+				}
+			case depth < leaving:
+				s.Decreases++
+				run = 0
+			default:
+				s.Flat++
+				run = 0
+			}
+		}
+		ring[next] = depth
+		next = (next + 1) % window
+		s.Readings++
+	}
+	return s, scanner.Err()
+}
+
+// ReadDepths reads all the depths, one per line.
+func ReadDepths(r io.Reader) ([]int, error) {
+	var depths []int
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		depth, err := parseDepth(scanner.Text(), len(depths)+1)
+		if err != nil {
+			return nil, err
+		}
+		depths = append(depths, depth)
+	}
+	return depths, scanner.Err()
+}
+
+func parseDepth(line string, lineno int) (int, error) {
+	depth, err := strconv.Atoi(strings.TrimSpace(line))
+	if err != nil {
+		return 0, input.Errorf(lineno, 1, line, "depth must be a number")
+	}
+	return depth, nil
+}
@@ -0,0 +1,59 @@
+package day02
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/kentquirk/aoc2021/input"
+	"github.com/kentquirk/aoc2021/registry"
+)
+
+type Submarine struct {
+	Aim      int
+	Position int
+	Depth    int
+}
+
+func (s *Submarine) Report() int {
+	return s.Position * s.Depth
+}
+
+// steer runs the input as a script in a dialect and reports where it ends up
+func steer(in *input.Input, d *Dialect) (interface{}, error) {
+	sc, err := Parse(in.Text, d)
+	if err != nil {
+		return nil, err
+	}
+	sub := new(Submarine)
+	sc.Run(sub)
+	return sub.Report(), nil
+}
+
+func partA(in *input.Input) (interface{}, error) {
+	return steer(in, Direct)
+}
+
+func partB(in *input.Input) (interface{}, error) {
+	return steer(in, Aimed)
+}
+
+// drawCourses plots the course the input takes in each dialect, side by side
+func drawCourses(in *input.Input, format string, w io.Writer) error {
+	if format != "svg" {
+		return fmt.Errorf("day 2 can be drawn as svg, not %q", format)
+	}
+	var ts []*Trajectory
+	for _, d := range []*Dialect{Direct, Aimed} {
+		sc, err := Parse(in.Text, d)
+		if err != nil {
+			return err
+		}
+		ts = append(ts, Record(sc, Submarine{}))
+	}
+	return WriteSVG(w, 600, 400, ts...)
+}
+
+func init() {
+	registry.Register(2, partA, partB)
+	registry.RegisterDrawer(2, drawCourses)
+}
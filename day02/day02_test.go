@@ -0,0 +1,302 @@
+package day02
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/kentquirk/aoc2021/bench"
+	"github.com/kentquirk/aoc2021/input"
+)
+
+const sample = "forward 5\ndown 5\nforward 8\nup 3\ndown 8\nforward 2"
+
+func TestScript(t *testing.T) {
+	tests := []struct {
+		name    string
+		script  string
+		dialect *Dialect
+		want    Submarine
+	}{
+		{"sample a", sample, Direct, Submarine{Position: 15, Depth: 10}},
+		{"sample b", sample, Aimed, Submarine{Aim: 10, Position: 15, Depth: 60}},
+		{"comments", "# go\nforward 5 # fast\n\n   # nothing\ndown 2", Direct, Submarine{Position: 5, Depth: 2}},
+		{"repeat", "repeat 3 {\n\tforward 2\n\tdown 1\n}\nup 1", Direct, Submarine{Position: 6, Depth: 2}},
+		{"nested repeats", "repeat 2 {\n repeat 3 {\n  forward 1\n }\n down 1\n}", Aimed, Submarine{Aim: 2, Position: 6, Depth: 3}},
+		{"repeat nothing", "repeat 0 {\n forward 9\n}\nrepeat 4 {\n}", Direct, Submarine{}},
+		{"macro", "macro dive {\n down 3\n forward 1\n}\ndive\nrepeat 2 {\n dive\n}", Aimed, Submarine{Aim: 9, Position: 3, Depth: 18}},
+		{"macro in a macro", "macro a {\n down 1\n}\nmacro b {\n a\n a\n}\nb\nforward 1", Aimed, Submarine{Aim: 2, Position: 1, Depth: 2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sc, err := Parse(tt.script, tt.dialect)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got Submarine
+			sc.Run(&got)
+			if got != tt.want {
+				t.Errorf("Run() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScriptErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		script string
+		line   int
+		col    int
+	}{
+		{"unknown command", "forward 1\nsideways 3", 2, 1},
+		{"indented unknown command", "repeat 2 {\n  sideways 3\n}", 2, 3},
+		{"bad distance", "forward five", 1, 9},
+		{"missing distance", "down", 1, 0},
+		{"bad count", "repeat lots {\n}", 1, 8},
+		{"negative count", "repeat -1 {\n}", 1, 8},
+		{"no brace", "repeat 3\nforward 1\n}", 1, 0},
+		{"never closed", "forward 1\nrepeat 3 {\nforward 1\n", 2, 0},
+		{"extra close", "forward 1\n}", 2, 1},
+		{"macro in a block", "repeat 1 {\n macro m {\n }\n}", 2, 2},
+		{"macro named after a command", "macro up {\n}", 1, 7},
+		{"macro defined twice", "macro m {\n}\nmacro m {\n}", 3, 7},
+		{"macro used too soon", "m\nmacro m {\n}", 1, 1},
+		{"macro with an argument", "macro m {\n}\nm 3", 3, 3},
+		{"macro calls itself", "macro m {\n m\n}", 2, 2},
+		{"comment hides the brace", "repeat 2 # {\n}", 1, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(tt.script, Direct)
+			var pe *input.ParseError
+			if !errors.As(err, &pe) {
+				t.Fatalf("Parse() error = %v, want a ParseError", err)
+			}
+			if pe.Line != tt.line || pe.Col != tt.col {
+				t.Errorf("Parse() error at %d:%d, want %d:%d (%v)", pe.Line, pe.Col, tt.line, tt.col, err)
+			}
+		})
+	}
+}
+
+func TestEach(t *testing.T) {
+	sc, err := Parse("macro m {\n down 1\n forward 2\n}\nrepeat 5 {\n m\n}", Direct)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var steps []Step
+	done := sc.Each(func(step Step) bool {
+		steps = append(steps, step)
+		return len(steps) < 3
+	})
+	want := []Step{{Line: 2, Command: "down", N: 1}, {Line: 3, Command: "forward", N: 2}, {Line: 2, Command: "down", N: 1}}
+	if done || len(steps) != len(want) {
+		t.Fatalf("Each() = %v after %v", done, steps)
+	}
+	for i := range want {
+		if steps[i] != want[i] {
+			t.Errorf("step %d = %+v, want %+v", i, steps[i], want[i])
+		}
+	}
+}
+
+func TestDialects(t *testing.T) {
+	for _, name := range []string{"direct", "aim"} {
+		d, found := LookupDialect(name)
+		if !found {
+			t.Fatalf("dialect %s isn't registered", name)
+		}
+		if got := d.Commands(); len(got) != 3 || got[0] != "down" || got[1] != "forward" || got[2] != "up" {
+			t.Errorf("dialect %s has commands %v", name, got)
+		}
+	}
+
+	// a new dialect can be made from scratch
+	reverse := NewDialect("reverse")
+	reverse.Handle("back", func(s *Submarine, n int) { s.Position -= n })
+	sc, err := Parse("back 4\nrepeat 2 {\n back 1\n}", reverse)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var sub Submarine
+	sc.Run(&sub)
+	if sub.Position != -6 {
+		t.Errorf("Position = %d, want -6", sub.Position)
+	}
+	if _, err := Parse("forward 1", reverse); err == nil {
+		t.Errorf("reverse dialect knows about forward")
+	}
+}
+
+func TestTrajectory(t *testing.T) {
+	sc, err := Parse(sample, Aimed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := Submarine{Depth: 100}
+	tr := Record(sc, start)
+	if tr.Len() != 6 || tr.At(0) != start {
+		t.Fatalf("recorded %d moves from %+v", tr.Len(), tr.At(0))
+	}
+	if want := (Submarine{Aim: 10, Position: 15, Depth: 160}); tr.End() != want {
+		t.Errorf("End() = %+v, want %+v", tr.End(), want)
+	}
+	for i, m := range tr.Moves {
+		if m.Before != tr.At(i) || m.After != tr.At(i+1) {
+			t.Errorf("move %d goes from %+v to %+v", i, m.Before, m.After)
+		}
+	}
+	// forward 8 with an aim of 5 goes 8 across and 40 down
+	if got, want := tr.Moves[2].Distance, 5+math.Hypot(8, 40); math.Abs(got-want) > 1e-9 {
+		t.Errorf("Distance = %v, want %v", got, want)
+	}
+
+	r := tr.Replay()
+	if _, ok := r.Prev(); ok {
+		t.Errorf("went back from the start")
+	}
+	if m, ok := r.Next(); !ok || m.Command != "forward" || r.State() != (Submarine{Position: 5, Depth: 100}) {
+		t.Errorf("Next() = %+v, %v", m, ok)
+	}
+	if err := r.Seek(4); err != nil || r.State() != (Submarine{Aim: 2, Position: 13, Depth: 140}) {
+		t.Errorf("Seek(4) = %v, at %+v", err, r.State())
+	}
+	if m, ok := r.Prev(); !ok || m.Command != "up" || r.Pos() != 3 {
+		t.Errorf("Prev() = %+v, %v, at %d", m, ok, r.Pos())
+	}
+	if err := r.Seek(7); err == nil {
+		t.Errorf("sought past the end")
+	}
+	r.Seek(6)
+	if _, ok := r.Next(); ok {
+		t.Errorf("went on past the end")
+	}
+}
+
+func TestExports(t *testing.T) {
+	var ts []*Trajectory
+	for _, d := range []*Dialect{Direct, Aimed} {
+		sc, err := Parse(sample, d)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ts = append(ts, Record(sc, Submarine{}))
+	}
+
+	var buf bytes.Buffer
+	if err := ts[1].WriteCSV(&buf); err != nil {
+		t.Fatal(err)
+	}
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 7 || records[0][0] != "move" {
+		t.Fatalf("CSV has %d records, starting with %v", len(records), records[0])
+	}
+	if got := strings.Join(records[3], ","); got != "3,3,forward,8,5,5,0,5,13,40,45.792" {
+		t.Errorf("third move = %s", got)
+	}
+
+	buf.Reset()
+	if err := WriteSVG(&buf, 300, 200, ts...); err != nil {
+		t.Fatal(err)
+	}
+	svg := buf.String()
+	if !strings.Contains(svg, `width="600"`) || strings.Count(svg, "<polyline") != 2 {
+		t.Errorf("SVG doesn't have two panels:\n%s", svg)
+	}
+	if !strings.Contains(svg, "aim: position 15, depth 60") {
+		t.Errorf("SVG doesn't label the aim dialect:\n%s", svg)
+	}
+}
+
+func TestPlan(t *testing.T) {
+	tests := []struct {
+		name     string
+		dialect  *Dialect
+		position int
+		depth    int
+		want     string
+		wantErr  error
+	}{
+		{"direct sample", Direct, 15, 10, "down 10\nforward 15\n", nil},
+		{"direct up", Direct, 3, -2, "up 2\nforward 3\n", nil},
+		{"direct nowhere", Direct, 0, 0, "", nil},
+		{"direct backward", Direct, -1, 5, "", ErrUnreachable},
+		{"aim sample", Aimed, 15, 60, "down 4\nforward 15\n", nil},
+		{"aim level", Aimed, 7, 0, "forward 7\n", nil},
+		{"aim divides", Aimed, 5, -15, "up 3\nforward 5\n", nil},
+		{"aim doesn't divide", Aimed, 7, 10, "forward 2\ndown 2\nforward 5\n", nil},
+		{"aim prime", Aimed, 4, 7, "forward 3\ndown 7\nforward 1\n", nil},
+		{"aim straight down", Aimed, 0, 3, "", ErrUnreachable},
+		{"aim backward", Aimed, -2, 0, "", ErrUnreachable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			steps, err := Plan(tt.dialect, tt.position, tt.depth)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Plan() error = %v, want %v", err, tt.wantErr)
+			}
+			if got := Format(steps); got != tt.want {
+				t.Errorf("Plan() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+	if _, err := Plan(NewDialect("other"), 1, 1); err == nil {
+		t.Errorf("planned for a dialect with no planner")
+	}
+}
+
+// shorter returns whether any list of fewer than n steps, each going at most
+// limit, gets to the target
+func shorter(d *Dialect, n int, limit int, sub Submarine, position int, depth int) bool {
+	if n <= 0 {
+		return false
+	}
+	if sub.Position == position && sub.Depth == depth {
+		return true
+	}
+	for _, c := range d.Commands() {
+		h, _ := d.Handler(c)
+		for x := 1; x <= limit; x++ {
+			next := sub
+			h(&next, x)
+			if shorter(d, n-1, limit, next, position, depth) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func TestPlanIsShortest(t *testing.T) {
+	for _, d := range []*Dialect{Direct, Aimed} {
+		for position := 0; position <= 6; position++ {
+			for depth := -8; depth <= 8; depth++ {
+				steps, err := Plan(d, position, depth)
+				if err != nil {
+					if !shorter(d, 4, 8, Submarine{}, position, depth) {
+						continue
+					}
+					t.Errorf("%s: %d,%d is reachable but Plan() = %v", d.Name, position, depth, err)
+				}
+				if shorter(d, len(steps), 8, Submarine{}, position, depth) {
+					t.Errorf("%s: there's a plan for %d,%d shorter than %v", d.Name, position, depth, steps)
+				}
+			}
+		}
+	}
+}
+
+func BenchmarkDay02a(b *testing.B) {
+	bench.Solver(b, partA, "input.txt")
+}
+
+func BenchmarkDay02b(b *testing.B) {
+	bench.Solver(b, partB, "input.txt")
+}
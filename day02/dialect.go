@@ -0,0 +1,92 @@
+package day02
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Handler carries out a command with its argument.
+type Handler func(s *Submarine, n int)
+
+// Dialect gives commands their meaning; the same script steers the
+// submarine differently depending on the dialect it's run in.
+type Dialect struct {
+	Name     string
+	handlers map[string]Handler
+}
+
+// keywords are part of the script grammar, so they can't be commands
+var keywords = map[string]bool{"repeat": true, "macro": true}
+
+func NewDialect(name string) *Dialect {
+	return &Dialect{Name: name, handlers: make(map[string]Handler)}
+}
+
+// Handle sets the handler for a command. Using a keyword as a command, or
+// handling the same one twice, is a programming error, so it panics.
+func (d *Dialect) Handle(command string, h Handler) {
+	if keywords[command] {
+		panic(fmt.Sprintf("%q is a keyword, not a command", command))
+	}
+	if _, found := d.handlers[command]; found {
+		panic(fmt.Sprintf("command %q handled twice in dialect %s", command, d.Name))
+	}
+	d.handlers[command] = h
+}
+
+// Handler returns the handler for a command.
+func (d *Dialect) Handler(command string) (Handler, bool) {
+	h, found := d.handlers[command]
+	return h, found
+}
+
+// Commands returns the names of the commands the dialect knows, sorted.
+func (d *Dialect) Commands() []string {
+	var result []string
+	for c := range d.handlers {
+		result = append(result, c)
+	}
+	sort.Strings(result)
+	return result
+}
+
+var dialects = make(map[string]*Dialect)
+
+// RegisterDialect makes a dialect available by name. Like the days, a
+// dialect registered twice panics.
+func RegisterDialect(d *Dialect) {
+	if _, found := dialects[d.Name]; found {
+		panic(fmt.Sprintf("dialect %s registered twice", d.Name))
+	}
+	dialects[d.Name] = d
+}
+
+// LookupDialect returns the dialect registered with a name.
+func LookupDialect(name string) (*Dialect, bool) {
+	d, found := dialects[name]
+	return d, found
+}
+
+// The dialects of the two parts of the puzzle.
+var (
+	// Direct is part A's, where up and down change the depth.
+	Direct = NewDialect("direct")
+	// Aimed is part B's, where up and down change the aim, and going
+	// forward follows it.
+	Aimed = NewDialect("aim")
+)
+
+func init() {
+	Direct.Handle("forward", func(s *Submarine, n int) { s.Position += n })
+	Direct.Handle("down", func(s *Submarine, n int) { s.Depth += n })
+	Direct.Handle("up", func(s *Submarine, n int) { s.Depth -= n })
+	RegisterDialect(Direct)
+
+	Aimed.Handle("forward", func(s *Submarine, n int) {
+		s.Position += n
+		s.Depth += s.Aim * n
+	})
+	Aimed.Handle("down", func(s *Submarine, n int) { s.Aim += n })
+	Aimed.Handle("up", func(s *Submarine, n int) { s.Aim -= n })
+	RegisterDialect(Aimed)
+}
@@ -0,0 +1,111 @@
+package day02
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrUnreachable means that no script can get the submarine to a target.
+var ErrUnreachable = errors.New("the target can't be reached")
+
+// a planner finds the shortest list of steps that gets a submarine from the
+// surface to a position and depth, or returns false if there isn't one.
+// Every step moves a distance of at least 1.
+type planner func(position int, depth int) ([]Step, bool)
+
+var planners = map[*Dialect]planner{
+	Direct: planDirect,
+	Aimed:  planAimed,
+}
+
+// vertical is the step that changes depth (or aim) by n
+func vertical(n int) Step {
+	if n < 0 {
+		return Step{Command: "up", N: -n}
+	}
+	return Step{Command: "down", N: n}
+}
+
+// In the direct dialect, going forward and going down are independent, so
+// it takes one step for each that's needed.
+func planDirect(position int, depth int) ([]Step, bool) {
+	if position < 0 {
+		return nil, false
+	}
+	var steps []Step
+	if depth != 0 {
+		steps = append(steps, vertical(depth))
+	}
+	if position != 0 {
+		steps = append(steps, Step{Command: "forward", N: position})
+	}
+	return steps, true
+}
+
+// In the aim dialect, only going forward changes the depth, so getting
+// anywhere but the surface takes at least an aim and a forward step. That's
+// enough when the position divides the depth. Otherwise, going forward a
+// little first leaves a shorter distance that does divide it, which there
+// always is, since 1 divides everything.
+func planAimed(position int, depth int) ([]Step, bool) {
+	switch {
+	case position < 0 || (position == 0 && depth != 0):
+		return nil, false
+	case position == 0:
+		return nil, true
+	case depth == 0:
+		return []Step{{Command: "forward", N: position}}, true
+	case depth%position == 0:
+		return []Step{vertical(depth / position), {Command: "forward", N: position}}, true
+	}
+	// the longest run that divides the depth makes for the smallest aim
+	last := position - 1
+	for depth%last != 0 {
+		last--
+	}
+	return []Step{
+		{Command: "forward", N: position - last},
+		vertical(depth / last),
+		{Command: "forward", N: last},
+	}, true
+}
+
+// Plan returns the shortest list of steps that takes a submarine from the
+// surface to the target in the given dialect, or ErrUnreachable if no list
+// of steps can. Before it's returned, the plan is checked by running it as
+// a script.
+func Plan(d *Dialect, position int, depth int) ([]Step, error) {
+	plan, found := planners[d]
+	if !found {
+		return nil, fmt.Errorf("there's no planner for dialect %s", d.Name)
+	}
+	steps, ok := plan(position, depth)
+	if !ok {
+		return nil, fmt.Errorf("position %d, depth %d in dialect %s: %w", position, depth, d.Name, ErrUnreachable)
+	}
+	for i := range steps {
+		steps[i].Line = i + 1
+	}
+
+	sc, err := Parse(Format(steps), d)
+	if err != nil {
+		return nil, err
+	}
+	var sub Submarine
+	sc.Run(&sub)
+	if sub.Position != position || sub.Depth != depth {
+		return nil, fmt.Errorf("the plan for position %d, depth %d in dialect %s ends up at position %d, depth %d",
+			position, depth, d.Name, sub.Position, sub.Depth)
+	}
+	return steps, nil
+}
+
+// Format writes steps as a script.
+func Format(steps []Step) string {
+	var sb strings.Builder
+	for _, s := range steps {
+		fmt.Fprintf(&sb, "%s %d\n", s.Command, s.N)
+	}
+	return sb.String()
+}
@@ -0,0 +1,202 @@
+package day02
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/kentquirk/aoc2021/input"
+)
+
+// A script is a list of commands, one per line, like "forward 5". It can
+// also have:
+//
+//	# comments, which run to the end of the line
+//	repeat 3 {
+//		commands to run three times
+//	}
+//	macro dive {
+//		commands to run wherever "dive" appears later on
+//	}
+//
+// Blocks can be nested, but macros are only defined at the top level, and
+// must be defined before they're used.
+
+// Step is a single command from a script, and the line it's on.
+type Step struct {
+	Line    int
+	Command string
+	N       int
+}
+
+// node is a step, or a block of nodes to run some number of times (a
+// repeat, or a macro call, which runs once)
+type node struct {
+	Step
+	times int
+	body  []node // nil for a step
+}
+
+// Script is a parsed script, ready to run.
+type Script struct {
+	Dialect *Dialect
+	nodes   []node
+	macros  map[string][]node
+}
+
+// word is a word on a line, and the column it starts in
+type word struct {
+	text string
+	col  int
+}
+
+func words(line string) []word {
+	var result []word
+	start := -1
+	for i, r := range line + " " {
+		switch {
+		case r == ' ' || r == '\t' || r == '\r':
+			if start >= 0 {
+				result = append(result, word{text: line[start:i], col: start + 1})
+				start = -1
+			}
+		case start < 0:
+			start = i
+		}
+	}
+	return result
+}
+
+// block is a repeat or macro that's being parsed
+type block struct {
+	line    int
+	keyword string
+	name    string
+	times   int
+	nodes   []node
+}
+
+// Parse reads a script, checking its commands against the dialect.
+func Parse(text string, d *Dialect) (*Script, error) {
+	sc := &Script{Dialect: d, macros: make(map[string][]node)}
+	stack := []*block{{}}
+	for i, line := range strings.Split(text, "\n") {
+		lineno := i + 1
+		code := line
+		if c := strings.IndexByte(code, '#'); c >= 0 {
+			code = code[:c]
+		}
+		ws := words(code)
+		if len(ws) == 0 {
+			continue
+		}
+		top := stack[len(stack)-1]
+		switch w := ws[0]; w.text {
+		case "}":
+			if len(ws) > 1 {
+				return nil, input.Errorf(lineno, ws[1].col, ws[1].text, "expected nothing after '}'")
+			}
+			if len(stack) == 1 {
+				return nil, input.Errorf(lineno, w.col, w.text, "there's no block to close")
+			}
+			stack = stack[:len(stack)-1]
+			body := top.nodes
+			if body == nil {
+				body = []node{}
+			}
+			if top.keyword == "macro" {
+				sc.macros[top.name] = body
+				continue
+			}
+			parent := stack[len(stack)-1]
+			parent.nodes = append(parent.nodes, node{
+				Step:  Step{Line: top.line, Command: top.keyword, N: top.times},
+				times: top.times,
+				body:  body,
+			})
+
+		case "repeat":
+			if len(ws) != 3 || ws[2].text != "{" {
+				return nil, input.Errorf(lineno, 0, line, "expected 'repeat N {'")
+			}
+			n, err := strconv.Atoi(ws[1].text)
+			if err != nil || n < 0 {
+				return nil, input.Errorf(lineno, ws[1].col, ws[1].text, "the repeat count must be a number, 0 or more")
+			}
+			stack = append(stack, &block{line: lineno, keyword: w.text, times: n})
+
+		case "macro":
+			if len(ws) != 3 || ws[2].text != "{" {
+				return nil, input.Errorf(lineno, 0, line, "expected 'macro NAME {'")
+			}
+			if len(stack) > 1 {
+				return nil, input.Errorf(lineno, w.col, w.text, "macros can only be defined at the top level")
+			}
+			name := ws[1]
+			_, isCommand := d.Handler(name.text)
+			_, isMacro := sc.macros[name.text]
+			if isCommand || isMacro || keywords[name.text] || name.text == "{" || name.text == "}" {
+				return nil, input.Errorf(lineno, name.col, name.text, "that name is already taken")
+			}
+			stack = append(stack, &block{line: lineno, keyword: w.text, name: name.text})
+
+		default:
+			if _, found := d.Handler(w.text); found {
+				if len(ws) != 2 {
+					return nil, input.Errorf(lineno, 0, line, "expected a command and a distance")
+				}
+				n, err := strconv.Atoi(ws[1].text)
+				if err != nil {
+					return nil, input.Errorf(lineno, ws[1].col, ws[1].text, "distance must be a number")
+				}
+				top.nodes = append(top.nodes, node{Step: Step{Line: lineno, Command: w.text, N: n}})
+				continue
+			}
+			if body, found := sc.macros[w.text]; found {
+				if len(ws) != 1 {
+					return nil, input.Errorf(lineno, ws[1].col, ws[1].text, "macros don't take arguments")
+				}
+				top.nodes = append(top.nodes, node{Step: Step{Line: lineno, Command: w.text}, times: 1, body: body})
+				continue
+			}
+			return nil, input.Errorf(lineno, w.col, w.text, "unknown command")
+		}
+	}
+	if len(stack) > 1 {
+		top := stack[len(stack)-1]
+		return nil, input.Errorf(top.line, 0, "", "this %s block is never closed", top.keyword)
+	}
+	sc.nodes = stack[0].nodes
+	return sc, nil
+}
+
+// Each calls f for each step the script takes, in order, with the repeats
+// and macros expanded. It stops early, returning false, if f does.
+func (sc *Script) Each(f func(step Step) bool) bool {
+	return each(sc.nodes, f)
+}
+
+func each(nodes []node, f func(step Step) bool) bool {
+	for _, n := range nodes {
+		if n.body == nil {
+			if !f(n.Step) {
+				return false
+			}
+			continue
+		}
+		for i := 0; i < n.times; i++ {
+			if !each(n.body, f) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Run steers the submarine through the script.
+func (sc *Script) Run(s *Submarine) {
+	sc.Each(func(step Step) bool {
+		h, _ := sc.Dialect.Handler(step.Command)
+		h(s, step.N)
+		return true
+	})
+}
@@ -0,0 +1,186 @@
+package day02
+
+import (
+	"encoding/csv"
+	"fmt"
+	"html"
+	"io"
+	"math"
+	"strconv"
+)
+
+// Move is one step of a trajectory: the command, where the submarine was
+// before and after it, and how far the submarine has gone so far.
+type Move struct {
+	Step
+	Before   Submarine
+	After    Submarine
+	Distance float64
+}
+
+// Trajectory is the whole course of a submarine through a script.
+type Trajectory struct {
+	Dialect *Dialect
+	Start   Submarine
+	Moves   []Move
+}
+
+// Record runs a script from the given start, keeping every move.
+func Record(sc *Script, start Submarine) *Trajectory {
+	t := &Trajectory{Dialect: sc.Dialect, Start: start}
+	sub := start
+	distance := 0.0
+	sc.Each(func(step Step) bool {
+		before := sub
+		h, _ := sc.Dialect.Handler(step.Command)
+		h(&sub, step.N)
+		distance += math.Hypot(float64(sub.Position-before.Position), float64(sub.Depth-before.Depth))
+		t.Moves = append(t.Moves, Move{Step: step, Before: before, After: sub, Distance: distance})
+		return true
+	})
+	return t
+}
+
+// Len returns the number of moves.
+func (t *Trajectory) Len() int {
+	return len(t.Moves)
+}
+
+// At returns where the submarine is after the first i moves; At(0) is the
+// start and At(Len()) is the end.
+func (t *Trajectory) At(i int) Submarine {
+	if i == 0 {
+		return t.Start
+	}
+	return t.Moves[i-1].After
+}
+
+// End returns where the submarine finishes.
+func (t *Trajectory) End() Submarine {
+	return t.At(t.Len())
+}
+
+// Replay steps through a trajectory, forward or back.
+type Replay struct {
+	t   *Trajectory
+	pos int
+}
+
+// Replay starts a replay at the beginning of the trajectory.
+func (t *Trajectory) Replay() *Replay {
+	return &Replay{t: t}
+}
+
+// Pos returns the number of moves that have been made.
+func (r *Replay) Pos() int {
+	return r.pos
+}
+
+// State returns where the submarine is now.
+func (r *Replay) State() Submarine {
+	return r.t.At(r.pos)
+}
+
+// Seek jumps to just after the first i moves.
+func (r *Replay) Seek(i int) error {
+	if i < 0 || i > r.t.Len() {
+		return fmt.Errorf("can't seek to move %d of %d", i, r.t.Len())
+	}
+	r.pos = i
+	return nil
+}
+
+// Next makes the next move and returns it, or returns false at the end.
+func (r *Replay) Next() (Move, bool) {
+	if r.pos >= r.t.Len() {
+		return Move{}, false
+	}
+	r.pos++
+	return r.t.Moves[r.pos-1], true
+}
+
+// Prev takes back the last move and returns it, or returns false at the
+// start.
+func (r *Replay) Prev() (Move, bool) {
+	if r.pos == 0 {
+		return Move{}, false
+	}
+	r.pos--
+	return r.t.Moves[r.pos], true
+}
+
+// WriteCSV writes a header and then one record per move.
+func (t *Trajectory) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"move", "line", "command", "n",
+		"aim_before", "position_before", "depth_before",
+		"aim_after", "position_after", "depth_after", "distance"})
+	for i, m := range t.Moves {
+		cw.Write([]string{
+			strconv.Itoa(i + 1), strconv.Itoa(m.Line), m.Command, strconv.Itoa(m.N),
+			strconv.Itoa(m.Before.Aim), strconv.Itoa(m.Before.Position), strconv.Itoa(m.Before.Depth),
+			strconv.Itoa(m.After.Aim), strconv.Itoa(m.After.Position), strconv.Itoa(m.After.Depth),
+			strconv.FormatFloat(m.Distance, 'f', 3, 64),
+		})
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// the margin around each plot, in pixels
+const margin = 20
+
+var colors = []string{"#1f4e96", "#c0392b", "#27ae60", "#8e44ad"}
+
+// WriteSVG plots depth against position for each trajectory, side by side
+// in panels of the given size, each with its own scale. Depth increases
+// down the page.
+func WriteSVG(w io.Writer, width int, height int, ts ...*Trajectory) error {
+	if width <= 2*margin || height <= 2*margin {
+		return fmt.Errorf("a plot must be more than %d pixels each way, not %dx%d", 2*margin, width, height)
+	}
+	fmt.Fprintf(w, "<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"%d\" height=\"%d\" viewBox=\"0 0 %d %d\">\n",
+		width*len(ts), height, width*len(ts), height)
+	fmt.Fprint(w, "<rect width=\"100%\" height=\"100%\" fill=\"#ffffff\"/>\n")
+	for i, t := range ts {
+		end := t.End()
+		fmt.Fprintf(w, "<g transform=\"translate(%d,0)\">\n", i*width)
+		fmt.Fprintf(w, "<rect x=\"0.5\" y=\"0.5\" width=\"%d\" height=\"%d\" fill=\"none\" stroke=\"#cccccc\"/>\n", width-1, height-1)
+		fmt.Fprintf(w, "<text x=\"%d\" y=\"%d\" font-family=\"sans-serif\" font-size=\"12\">%s: position %d, depth %d</text>\n",
+			margin, margin-6, html.EscapeString(t.Dialect.Name), end.Position, end.Depth)
+		fmt.Fprintf(w, "<polyline fill=\"none\" stroke=\"%s\" stroke-width=\"1\" points=\"", colors[i%len(colors)])
+		lo, hi := t.Start, t.Start
+		for _, m := range t.Moves {
+			lo.Position, hi.Position = extend(lo.Position, hi.Position, m.After.Position)
+			lo.Depth, hi.Depth = extend(lo.Depth, hi.Depth, m.After.Depth)
+		}
+		for j := 0; j <= t.Len(); j++ {
+			s := t.At(j)
+			if j > 0 {
+				fmt.Fprint(w, " ")
+			}
+			fmt.Fprintf(w, "%.1f,%.1f", scale(s.Position, lo.Position, hi.Position, width), scale(s.Depth, lo.Depth, hi.Depth, height))
+		}
+		fmt.Fprint(w, "\"/>\n</g>\n")
+	}
+	_, err := fmt.Fprint(w, "</svg>\n")
+	return err
+}
+
+func extend(lo int, hi int, v int) (int, int) {
+	if v < lo {
+		lo = v
+	}
+	if v > hi {
+		hi = v
+	}
+	return lo, hi
+}
+
+// scale maps v, which is between lo and hi, onto a panel of the given size
+func scale(v int, lo int, hi int, size int) float64 {
+	if hi == lo {
+		return float64(size) / 2
+	}
+	return margin + float64(v-lo)*float64(size-2*margin)/float64(hi-lo)
+}
@@ -0,0 +1,99 @@
+package day03
+
+import (
+	"math/big"
+	"math/bits"
+	"strings"
+
+	"github.com/kentquirk/aoc2021/input"
+)
+
+// Bits is a fixed-width row of bits, as wide as it needs to be. Bit 0 is the
+// least significant, which is the rightmost column of a report.
+type Bits struct {
+	width int
+	words []uint64
+}
+
+func NewBits(width int) Bits {
+	return Bits{width: width, words: make([]uint64, (width+63)/64)}
+}
+
+// ParseBits reads a string of 0s and 1s, most significant first.
+func ParseBits(s string) (Bits, error) {
+	b := NewBits(len(s))
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '1':
+			b.Set(len(s)-1-i, true)
+		case '0':
+		default:
+			return Bits{}, input.Errorf(1, i+1, s[i:i+1], "not a binary digit")
+		}
+	}
+	return b, nil
+}
+
+func (b Bits) Width() int {
+	return b.width
+}
+
+// Get returns whether bit i is set.
+func (b Bits) Get(i int) bool {
+	return b.words[i/64]&(1<<(i%64)) != 0
+}
+
+// Set sets or clears bit i.
+func (b Bits) Set(i int, v bool) {
+	if v {
+		b.words[i/64] |= 1 << (i % 64)
+	} else {
+		b.words[i/64] &^= 1 << (i % 64)
+	}
+}
+
+// Not returns a copy of b with every bit flipped.
+func (b Bits) Not() Bits {
+	result := NewBits(b.width)
+	for i, w := range b.words {
+		result.words[i] = ^w
+	}
+	if extra := b.width % 64; extra != 0 {
+		result.words[len(result.words)-1] &= 1<<extra - 1
+	}
+	return result
+}
+
+// OnesCount returns the number of bits that are set.
+func (b Bits) OnesCount() int {
+	n := 0
+	for _, w := range b.words {
+		n += bits.OnesCount64(w)
+	}
+	return n
+}
+
+// Int returns the value of b as a number.
+func (b Bits) Int() *big.Int {
+	buf := make([]byte, 8*len(b.words))
+	for i, w := range b.words {
+		for j := 0; j < 8; j++ {
+			buf[len(buf)-1-8*i-j] = byte(w >> (8 * j))
+		}
+	}
+	return new(big.Int).SetBytes(buf)
+}
+
+// String returns b as 0s and 1s, most significant first.
+func (b Bits) String() string {
+	var sb strings.Builder
+	sb.Grow(b.width)
+	for i := b.width - 1; i >= 0; i-- {
+		if b.Get(i) {
+			sb.WriteByte('1')
+		} else {
+			sb.WriteByte('0')
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,89 @@
+package day03
+
+import (
+	"math/big"
+	"math/bits"
+
+	"github.com/kentquirk/aoc2021/input"
+	"github.com/kentquirk/aoc2021/registry"
+)
+
+// Report is a diagnostic report: rows of bits that are all the same width.
+type Report struct {
+	Width int
+	Rows  []Bits
+}
+
+func parse(data []string) (*Report, error) {
+	if len(data) == 0 {
+		return nil, input.Errorf(1, 0, "", "the report is empty")
+	}
+	r := &Report{Width: len(data[0])}
+	for i, d := range data {
+		row, err := ParseBits(d)
+		if err != nil {
+			return nil, input.Offset(err, i+1)
+		}
+		if row.Width() != r.Width {
+			return nil, input.Errorf(i+1, 0, d, "expected %d bits, found %d", r.Width, len(d))
+		}
+		r.Rows = append(r.Rows, row)
+	}
+	return r, nil
+}
+
+// ones counts the rows that have each bit set
+func ones(rows []Bits, width int) []int {
+	counts := make([]int, width)
+	for _, row := range rows {
+		for i, w := range row.words {
+			for ; w != 0; w &= w - 1 {
+				counts[64*i+bits.TrailingZeros64(w)]++
+			}
+		}
+	}
+	return counts
+}
+
+func day03a(r *Report) (Bits, Bits) {
+	gamma := NewBits(r.Width)
+	for b, numOnes := range ones(r.Rows, r.Width) {
+		if numOnes > len(r.Rows)-numOnes {
+			gamma.Set(b, true)
+		}
+	}
+	return gamma, gamma.Not()
+}
+
+func day03b(r *Report, rule Rule) (Bits, error) {
+	return r.Rate(rule).Rating()
+}
+
+func partA(in *input.Input) (interface{}, error) {
+	r, err := parse(in.Lines())
+	if err != nil {
+		return nil, err
+	}
+	gamma, epsilon := day03a(r)
+	return new(big.Int).Mul(gamma.Int(), epsilon.Int()), nil
+}
+
+func partB(in *input.Input) (interface{}, error) {
+	r, err := parse(in.Lines())
+	if err != nil {
+		return nil, err
+	}
+	oxygen, err := day03b(r, Oxygen)
+	if err != nil {
+		return nil, err
+	}
+	co2, err := day03b(r, CO2)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).Mul(oxygen.Int(), co2.Int()), nil
+}
+
+func init() {
+	registry.Register(3, partA, partB)
+}
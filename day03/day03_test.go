@@ -0,0 +1,199 @@
+package day03
+
+import (
+	"math/big"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/kentquirk/aoc2021/bench"
+)
+
+var sample = []string{
+	"00100", "11110", "10110", "10111", "10101", "01111",
+	"00111", "11100", "10000", "11001", "00010", "01010",
+}
+
+func TestBits(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		ones int
+		not  string
+	}{
+		{"empty", "", 0, ""},
+		{"small", "10110", 3, "01001"},
+		{"one word", strings.Repeat("1", 64), 64, strings.Repeat("0", 64)},
+		{"two words", "1" + strings.Repeat("0", 64), 1, "0" + strings.Repeat("1", 64)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := ParseBits(tt.s)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if b.String() != tt.s || b.Width() != len(tt.s) {
+				t.Errorf("String() = %q", b.String())
+			}
+			if b.OnesCount() != tt.ones {
+				t.Errorf("OnesCount() = %d, want %d", b.OnesCount(), tt.ones)
+			}
+			if not := b.Not(); not.String() != tt.not || not.OnesCount() != len(tt.s)-tt.ones {
+				t.Errorf("Not() = %q, want %q", not.String(), tt.not)
+			}
+			want, _ := new(big.Int).SetString("0"+tt.s, 2)
+			if b.Int().Cmp(want) != 0 {
+				t.Errorf("Int() = %v, want %v", b.Int(), want)
+			}
+		})
+	}
+	if _, err := ParseBits("10201"); err == nil {
+		t.Errorf("ParseBits() accepted a 2")
+	}
+}
+
+// widen makes a report as wide as the sample repeated n times over; the
+// answers are the sample's, repeated the same way
+func widen(n int) []string {
+	var rows []string
+	for _, s := range sample {
+		rows = append(rows, strings.Repeat(s, n))
+	}
+	return rows
+}
+
+func rating(t *testing.T, r *Report, rule Rule) Bits {
+	b, err := day03b(r, rule)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func TestWideReport(t *testing.T) {
+	r, err := parse(widen(40))
+	if err != nil {
+		t.Fatal(err)
+	}
+	gamma, epsilon := day03a(r)
+	tests := []struct {
+		name string
+		got  Bits
+		want string
+	}{
+		{"gamma", gamma, "10110"},
+		{"epsilon", epsilon, "01001"},
+		{"oxygen", rating(t, r, Oxygen), "10111"},
+		{"co2", rating(t, r, CO2), "01010"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if want := strings.Repeat(tt.want, 40); tt.got.String() != want {
+				t.Errorf("got %s, want %s", tt.got, want)
+			}
+		})
+	}
+}
+
+func TestRate(t *testing.T) {
+	r, err := parse(sample)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name      string
+		rule      Rule
+		want      string
+		bits      []int
+		survivors []int
+	}{
+		{"oxygen", Oxygen, "10111", []int{4, 3, 2, 1, 0}, []int{7, 4, 3, 2, 1}},
+		{"co2", CO2, "01010", []int{4, 3, 2}, []int{5, 2, 1}},
+		// 5 rows end in 1, then 2 of them have a 0 next, then there's a tie
+		{"lsb first", Rule{Criterion: LeastCommon, Tie: KeepOnes, Order: LSBFirst}, "10101", []int{0, 1, 2}, []int{5, 2, 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			trace := r.Rate(tt.rule)
+			got, err := trace.Rating()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.String() != tt.want {
+				t.Errorf("Rating() = %s, want %s", got, tt.want)
+			}
+			if len(trace.Rounds) != len(tt.bits) {
+				t.Fatalf("%d rounds, want %d", len(trace.Rounds), len(tt.bits))
+			}
+			left := len(sample)
+			for i, round := range trace.Rounds {
+				if round.Bit != tt.bits[i] || len(round.Survivors) != tt.survivors[i] {
+					t.Errorf("round %d looked at bit %d and left %d, want bit %d and %d",
+						i, round.Bit, len(round.Survivors), tt.bits[i], tt.survivors[i])
+				}
+				if round.Zeroes+round.Ones != left {
+					t.Errorf("round %d counted %d rows, want %d", i, round.Zeroes+round.Ones, left)
+				}
+				left = len(round.Survivors)
+			}
+		})
+	}
+
+	// oxygen comes down to a tie at the last bit between 10110 and 10111
+	trace := r.Rate(Rule{Criterion: MostCommon, Tie: KeepBoth})
+	if _, err := trace.Rating(); err == nil || len(trace.Survivors) != 2 || trace.Rounds[4].Kept != -1 {
+		t.Errorf("Rating() = %v with %v left", err, trace.Survivors)
+	}
+	// duplicates agree on every bit, so they're never split up
+	r, _ = parse([]string{"11", "11"})
+	trace = r.Rate(CO2)
+	if got, err := trace.Rating(); err != nil || got.String() != "11" || trace.Rounds[0].Kept != -1 {
+		t.Errorf("Rating() = %v, %v", got, err)
+	}
+}
+
+func BenchmarkDay03a(b *testing.B) {
+	bench.Solver(b, partA, "input.txt")
+}
+
+func BenchmarkDay03b(b *testing.B) {
+	bench.Solver(b, partB, "input.txt")
+}
+
+// synthetic makes a report of random rows that are 10,000 bits wide
+func synthetic(b *testing.B) *Report {
+	rnd := rand.New(rand.NewSource(3))
+	rows := make([]string, 1000)
+	var sb strings.Builder
+	for i := range rows {
+		sb.Reset()
+		for j := 0; j < 10000; j++ {
+			sb.WriteByte(byte('0' + rnd.Intn(2)))
+		}
+		rows[i] = sb.String()
+	}
+	r, err := parse(rows)
+	if err != nil {
+		b.Fatal(err)
+	}
+	return r
+}
+
+func BenchmarkWideA(b *testing.B) {
+	r := synthetic(b)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		gamma, epsilon := day03a(r)
+		new(big.Int).Mul(gamma.Int(), epsilon.Int())
+	}
+}
+
+func BenchmarkWideB(b *testing.B) {
+	r := synthetic(b)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		oxygen, _ := day03b(r, Oxygen)
+		co2, _ := day03b(r, CO2)
+		new(big.Int).Mul(oxygen.Int(), co2.Int())
+	}
+}
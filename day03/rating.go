@@ -0,0 +1,127 @@
+package day03
+
+import "fmt"
+
+// Criterion says which value of a bit the candidates should have to
+// survive: the one that most of them have, or the one that fewest do.
+type Criterion int
+
+const (
+	MostCommon Criterion = iota
+	LeastCommon
+)
+
+// TiePolicy says what happens when a bit is 1 for exactly half the
+// candidates.
+type TiePolicy int
+
+const (
+	KeepOnes TiePolicy = iota
+	KeepZeroes
+	KeepBoth
+)
+
+// ScanOrder says which end of the rows to start looking at.
+type ScanOrder int
+
+const (
+	MSBFirst ScanOrder = iota
+	LSBFirst
+)
+
+// Rule is how to narrow down a report to a single row, one bit at a time.
+type Rule struct {
+	Criterion Criterion
+	Tie       TiePolicy
+	Order     ScanOrder
+}
+
+// The ratings from the puzzle.
+var (
+	Oxygen = Rule{Criterion: MostCommon, Tie: KeepOnes, Order: MSBFirst}
+	CO2    = Rule{Criterion: LeastCommon, Tie: KeepZeroes, Order: MSBFirst}
+)
+
+// Round records what happened at one bit.
+type Round struct {
+	Bit    int
+	Zeroes int
+	Ones   int
+	// Kept is the value that the survivors have: 0, 1, or -1 if everyone
+	// survived.
+	Kept int
+	// Survivors are the indexes in the report of the rows that are left.
+	Survivors []int
+}
+
+// Trace is the full story of a rating: the rounds in the order they
+// happened, and the rows left at the end.
+type Trace struct {
+	Report    *Report
+	Rounds    []Round
+	Survivors []int
+}
+
+// keep decides which value survives a round, or -1 for both
+func (rule Rule) keep(zeroes int, ones int) int {
+	switch {
+	case zeroes == 0 || ones == 0:
+		// everyone agrees, so nobody is eliminated
+		return -1
+	case zeroes == ones && rule.Tie == KeepBoth:
+		return -1
+	case zeroes == ones && rule.Tie == KeepOnes:
+		return 1
+	case zeroes == ones:
+		return 0
+	case (ones > zeroes) == (rule.Criterion == MostCommon):
+		return 1
+	}
+	return 0
+}
+
+// Rate narrows the rows of the report down, one bit at a time in the
+// rule's order, until only one is left or there are no more bits.
+func (r *Report) Rate(rule Rule) *Trace {
+	t := &Trace{Report: r, Survivors: make([]int, len(r.Rows))}
+	for i := range r.Rows {
+		t.Survivors[i] = i
+	}
+	for n := 0; n < r.Width && len(t.Survivors) > 1; n++ {
+		b := r.Width - 1 - n
+		if rule.Order == LSBFirst {
+			b = n
+		}
+		round := Round{Bit: b}
+		for _, i := range t.Survivors {
+			if r.Rows[i].Get(b) {
+				round.Ones++
+			}
+		}
+		round.Zeroes = len(t.Survivors) - round.Ones
+		round.Kept = rule.keep(round.Zeroes, round.Ones)
+		for _, i := range t.Survivors {
+			if round.Kept == -1 || r.Rows[i].Get(b) == (round.Kept == 1) {
+				round.Survivors = append(round.Survivors, i)
+			}
+		}
+		t.Rounds = append(t.Rounds, round)
+		t.Survivors = round.Survivors
+	}
+	return t
+}
+
+// Rating returns the row that the report was narrowed down to. It's an
+// error if the rows left at the end aren't all the same.
+func (t *Trace) Rating() (Bits, error) {
+	if len(t.Survivors) == 0 {
+		return Bits{}, fmt.Errorf("the report has no rows")
+	}
+	first := t.Report.Rows[t.Survivors[0]]
+	for _, i := range t.Survivors[1:] {
+		if t.Report.Rows[i].String() != first.String() {
+			return Bits{}, fmt.Errorf("%d different rows are left", len(t.Survivors))
+		}
+	}
+	return first, nil
+}
@@ -0,0 +1,232 @@
+package day04
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/kentquirk/aoc2021/input"
+	"github.com/kentquirk/aoc2021/registry"
+)
+
+type Square struct {
+	Value  int
+	Marked bool
+}
+
+type Board struct {
+	Size    int
+	Squares []Square
+	Win     bool
+	// WonBy is the pattern that won, once the board has won.
+	WonBy Pattern
+}
+
+func (b Board) String() string {
+	s := strings.Builder{}
+	s.WriteRune('\n')
+	for i := range b.Squares {
+		selected := " "
+		if b.Squares[i].Marked {
+			selected = "*"
+		}
+		s.WriteString(fmt.Sprintf("%2d%s ", b.Squares[i].Value, selected))
+		if i%b.Size == b.Size-1 {
+			s.WriteRune('\n')
+		}
+	}
+	return s.String()
+}
+
+func (b *Board) MarkMatching(value int) bool {
+	if b.Win {
+		return false
+	}
+	for i, s := range b.Squares {
+		if s.Value == value {
+			b.Squares[i].Marked = true
+			return true
+		}
+	}
+	return false
+}
+
+// CheckForWin looks for a pattern whose squares are all marked. The first
+// one found is recorded as the one that won.
+func (b *Board) CheckForWin(patterns []Pattern) bool {
+	if b.Win {
+		return true
+	}
+	for _, p := range patterns {
+		found := true
+		for _, ix := range p.Cells {
+			if !b.Squares[ix].Marked {
+				found = false
+				break
+			}
+		}
+		if found {
+			b.Win = true
+			b.WonBy = p
+			return true
+		}
+	}
+	return false
+}
+
+func (b Board) Score(lastNumber int) int {
+	var sum int
+	for i := range b.Squares {
+		if !b.Squares[i].Marked {
+			sum += b.Squares[i].Value
+		}
+	}
+	return sum * lastNumber
+}
+
+func parse(s string) ([]int, error) {
+	numpat := regexp.MustCompile("[^, \n\t]+")
+
+	var result []int
+	for _, ix := range numpat.FindAllStringIndex(s, -1) {
+		x, err := strconv.Atoi(s[ix[0]:ix[1]])
+		if err != nil {
+			line, col := input.Position(s, ix[0])
+			return nil, input.Errorf(line, col, s[ix[0]:ix[1]], "not a number")
+		}
+		result = append(result, x)
+	}
+	return result, nil
+}
+
+// NewBoard reads a square board, one row per line; its size is the number
+// of rows.
+func NewBoard(s string) (*Board, error) {
+	lines := strings.Split(strings.TrimRight(s, "\n"), "\n")
+	board := &Board{Size: len(lines)}
+	for i, line := range lines {
+		values, err := parse(line)
+		if err != nil {
+			return nil, input.Offset(err, i+1)
+		}
+		if len(values) != board.Size {
+			return nil, input.Errorf(i+1, 0, line, "a board with %d rows needs %d numbers in each row, not %d",
+				board.Size, board.Size, len(values))
+		}
+		for _, v := range values {
+			board.Squares = append(board.Squares, Square{Value: v})
+		}
+	}
+	return board, nil
+}
+
+// day04a returns the first board to win, and its score
+func day04a(draws []int, boards []*Board, patterns []Pattern) (*Board, int) {
+	w, found := Play(draws, boards, patterns).First()
+	if !found {
+		return nil, -1
+	}
+	return boards[w.Board], w.Score
+}
+
+// day04b returns the last board to win, and its score
+func day04b(draws []int, boards []*Board, patterns []Pattern) (*Board, int) {
+	w, found := Play(draws, boards, patterns).Last()
+	if !found {
+		return nil, -1
+	}
+	return boards[w.Board], w.Score
+}
+
+// scanFirst and scanLast are day04a and day04b the slow way, looking at
+// every square of every board for each draw
+func scanFirst(draws []int, boards []*Board, patterns []Pattern) (*Board, int) {
+	for _, draw := range draws {
+		for _, b := range boards {
+			b.MarkMatching(draw)
+			if b.CheckForWin(patterns) {
+				return b, b.Score(draw)
+			}
+		}
+	}
+	return nil, -1
+}
+
+func scanLast(draws []int, boards []*Board, patterns []Pattern) (*Board, int) {
+	for _, draw := range draws {
+		nonwins := 0
+		var last *Board
+		score := 0
+		for _, b := range boards {
+			if !b.Win {
+				b.MarkMatching(draw)
+				if b.CheckForWin(patterns) {
+					last = b
+					score = b.Score(draw)
+				} else {
+					nonwins++
+				}
+			}
+		}
+		if nonwins == 0 {
+			return last, score
+		}
+	}
+	return nil, -1
+}
+
+func parseInput(in *input.Input) ([]int, []*Board, error) {
+	blocks := in.Blocks()
+	if len(blocks) < 2 {
+		return nil, nil, input.Errorf(1, 0, "", "expected the draws followed by at least one board")
+	}
+	draws, err := parse(blocks[0])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var boards []*Board
+	for i, block := range blocks[1:] {
+		board, err := NewBoard(block)
+		if err != nil {
+			return nil, nil, input.Offset(err, in.BlockLine(i+1))
+		}
+		if len(boards) > 0 && board.Size != boards[0].Size {
+			return nil, nil, input.Errorf(in.BlockLine(i+1), 0, "", "this board is %dx%d, but the first one is %dx%d",
+				board.Size, board.Size, boards[0].Size, boards[0].Size)
+		}
+		boards = append(boards, board)
+	}
+	return draws, boards, nil
+}
+
+func partA(in *input.Input) (interface{}, error) {
+	draws, boards, err := parseInput(in)
+	if err != nil {
+		return nil, err
+	}
+	patterns, err := Patterns(boards[0].Size, Standard...)
+	if err != nil {
+		return nil, err
+	}
+	_, score := day04a(draws, boards, patterns)
+	return score, nil
+}
+
+func partB(in *input.Input) (interface{}, error) {
+	draws, boards, err := parseInput(in)
+	if err != nil {
+		return nil, err
+	}
+	patterns, err := Patterns(boards[0].Size, Standard...)
+	if err != nil {
+		return nil, err
+	}
+	_, score := day04b(draws, boards, patterns)
+	return score, nil
+}
+
+func init() {
+	registry.Register(4, partA, partB)
+}
@@ -0,0 +1,350 @@
+package day04
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/kentquirk/aoc2021/bench"
+	"github.com/kentquirk/aoc2021/input"
+)
+
+func TestPatterns(t *testing.T) {
+	tests := []struct {
+		name  string
+		kinds []PatternFunc
+		size  int
+		want  []Pattern
+	}{
+		{"rows", []PatternFunc{Rows}, 2, []Pattern{{"row 1", []int{0, 1}}, {"row 2", []int{2, 3}}}},
+		{"columns", []PatternFunc{Columns}, 2, []Pattern{{"column 1", []int{0, 2}}, {"column 2", []int{1, 3}}}},
+		{"diagonals", []PatternFunc{Diagonals}, 3, []Pattern{{"diagonal", []int{0, 4, 8}}, {"antidiagonal", []int{6, 4, 2}}}},
+		{"corners", []PatternFunc{Corners}, 3, []Pattern{{"corners", []int{0, 2, 6, 8}}}},
+		{"blackout", []PatternFunc{Blackout}, 2, []Pattern{{"blackout", []int{0, 1, 2, 3}}}},
+		{"mask", []PatternFunc{Mask("plus", ".X.\nXXX\n.X.")}, 3, []Pattern{{"plus", []int{1, 3, 4, 5, 7}}}},
+		{"standard", Standard, 1, []Pattern{{"row 1", []int{0}}, {"column 1", []int{0}}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Patterns(tt.size, tt.kinds...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Patterns() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	for _, kind := range []PatternFunc{
+		Corners,
+		Mask("too big", "XX\nXX"),
+		Mask("too wide", "XX"),
+		Mask("empty", "."),
+		Mask("odd", "O"),
+	} {
+		if _, err := Patterns(1, kind); err == nil {
+			t.Errorf("made a pattern that doesn't fit a 1x1 board")
+		}
+	}
+}
+
+func sampleGame(t *testing.T) ([]int, []*Board) {
+	in, err := input.Load("inputsample.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	draws, boards, err := parseInput(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return draws, boards
+}
+
+func TestWinningPatterns(t *testing.T) {
+	tests := []struct {
+		name    string
+		kinds   []PatternFunc
+		last    bool
+		board   int
+		pattern string
+		score   int
+	}{
+		{"first", Standard, false, 2, "row 1", 4512},
+		{"last", Standard, true, 1, "column 3", 1924},
+		{"first diagonal", []PatternFunc{Diagonals}, false, 2, "antidiagonal", 494},
+		{"first corners", []PatternFunc{Corners}, false, 2, "corners", 3262},
+		{"first blackout", []PatternFunc{Blackout}, false, 1, "blackout", 0},
+		{"first X", []PatternFunc{Mask("X", "X...X\n.X.X.\n..X..\n.X.X.\nX...X")}, false, 2, "X", 858},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			draws, boards := sampleGame(t)
+			patterns, err := Patterns(5, tt.kinds...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			play := day04a
+			if tt.last {
+				play = day04b
+			}
+			winner, score := play(draws, boards, patterns)
+			if winner != boards[tt.board] || winner.WonBy.Name != tt.pattern || score != tt.score {
+				t.Errorf("board %d won with %s, scoring %d", index(boards, winner), winner.WonBy.Name, score)
+			}
+		})
+	}
+}
+
+func index(boards []*Board, b *Board) int {
+	for i := range boards {
+		if boards[i] == b {
+			return i
+		}
+	}
+	return -1
+}
+
+func TestBoardSize(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		size    int
+		wantErr bool
+	}{
+		{"3x3", "1 2 3\n4 5 6\n7 8 9\n", 3, false},
+		{"1x1", "42", 1, false},
+		{"not square", "1 2 3\n4 5 6", 0, true},
+		{"ragged", "1 2\n3", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := NewBoard(tt.text)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewBoard() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && (b.Size != tt.size || len(b.Squares) != tt.size*tt.size) {
+				t.Errorf("NewBoard() made a %dx%d board with %d squares", b.Size, b.Size, len(b.Squares))
+			}
+		})
+	}
+
+	in := &input.Input{Name: "mixed", Text: "1,2\n\n1 2\n3 4\n\n1 2 3\n4 5 6\n7 8 9"}
+	if _, _, err := parseInput(in); err == nil {
+		t.Errorf("parseInput() accepted boards of different sizes")
+	}
+}
+
+// randomGame makes boards of distinct numbers below limit, and draws
+// every number below limit in some order
+func randomGame(seed int64, nboards int, size int, limit int) ([]int, []*Board) {
+	rnd := rand.New(rand.NewSource(seed))
+	boards := make([]*Board, nboards)
+	for i := range boards {
+		boards[i] = &Board{Size: size}
+		for _, v := range rnd.Perm(limit)[:size*size] {
+			boards[i].Squares = append(boards[i].Squares, Square{Value: v})
+		}
+	}
+	return rnd.Perm(limit), boards
+}
+
+func copyBoards(boards []*Board) []*Board {
+	result := make([]*Board, len(boards))
+	for i, b := range boards {
+		c := *b
+		c.Squares = append([]Square(nil), b.Squares...)
+		result[i] = &c
+	}
+	return result
+}
+
+func TestTournament(t *testing.T) {
+	kinds := [][]PatternFunc{
+		Standard,
+		{Diagonals, Corners},
+		{Rows, Columns, Diagonals, Blackout},
+		{Blackout},
+	}
+	for seed := int64(1); seed <= 20; seed++ {
+		size := 3 + int(seed)%4
+		draws, boards := randomGame(seed, 50, size, size*size*3)
+		for k, kind := range kinds {
+			t.Run(fmt.Sprintf("seed %d, patterns %d", seed, k), func(t *testing.T) {
+				patterns, err := Patterns(size, kind...)
+				if err != nil {
+					t.Fatal(err)
+				}
+				for _, p := range []struct {
+					name          string
+					indexed, scan func([]int, []*Board, []Pattern) (*Board, int)
+				}{{"first", day04a, scanFirst}, {"last", day04b, scanLast}} {
+					b1, b2 := copyBoards(boards), copyBoards(boards)
+					w1, s1 := p.indexed(draws, b1, patterns)
+					w2, s2 := p.scan(draws, b2, patterns)
+					if index(b1, w1) != index(b2, w2) || s1 != s2 || w1.WonBy.Name != w2.WonBy.Name {
+						t.Errorf("%s: indexed board %d scored %d with %s, but scanning found board %d scoring %d with %s",
+							p.name, index(b1, w1), s1, w1.WonBy.Name, index(b2, w2), s2, w2.WonBy.Name)
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestGame(t *testing.T) {
+	draws, boards := sampleGame(t)
+	patterns, _ := Patterns(5, Standard...)
+	g := Play(draws, boards, patterns)
+	if len(g.Wins) != 3 {
+		t.Fatalf("%d wins, want 3: %v", len(g.Wins), g.Wins)
+	}
+	tests := []struct {
+		name  string
+		got   Win
+		board int
+		draw  int
+		num   int
+		score int
+	}{
+		{"first", first(g.First()), 2, 11, 24, 4512},
+		{"second", first(g.Nth(1)), 0, 13, 16, 2192},
+		{"last", first(g.Last()), 1, 14, 13, 1924},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := tt.got
+			if w.Board != tt.board || w.Draw != tt.draw || w.Number != tt.num || w.Score != tt.score {
+				t.Errorf("got %+v", w)
+			}
+		})
+	}
+	if _, found := g.Nth(3); found {
+		t.Errorf("found a fourth winner")
+	}
+	for _, after := range []struct{ k, wins int }{{0, 0}, {11, 0}, {12, 1}, {14, 2}, {15, 3}, {27, 3}} {
+		if got := len(g.After(after.k)); got != after.wins {
+			t.Errorf("After(%d) has %d wins, want %d", after.k, got, after.wins)
+		}
+	}
+	if losers := g.Losers(); len(losers) != 0 {
+		t.Errorf("Losers() = %v", losers)
+	}
+
+	// stop drawing just after the first win
+	draws, boards = sampleGame(t)
+	g = Play(draws[:12], boards, patterns)
+	if w, _ := g.Last(); w.Board != 2 || !reflect.DeepEqual(g.Losers(), []int{0, 1}) {
+		t.Errorf("after 12 draws, %d won and %v lost", w.Board, g.Losers())
+	}
+	g = Play(nil, boards[:1], patterns)
+	if _, found := g.First(); found {
+		t.Errorf("someone won without any draws")
+	}
+}
+
+// first drops the found flag, for a win that's known to be there
+func first(w Win, found bool) Win {
+	return w
+}
+
+func TestEstimate(t *testing.T) {
+	tests := []struct {
+		k, n      int
+		low, high float64
+	}{
+		{50, 100, 0.4038, 0.5962},
+		{0, 100, 0, 0.0370},
+		{100, 100, 0.9630, 1},
+		{1, 1, 0.2065, 1},
+	}
+	for _, tt := range tests {
+		e := estimate(tt.k, tt.n)
+		if math.Abs(e.Low-tt.low) > 1e-4 || math.Abs(e.High-tt.high) > 1e-4 || e.P < e.Low || e.P > e.High {
+			t.Errorf("estimate(%d, %d) = %v, want %.4f-%.4f", tt.k, tt.n, e, tt.low, tt.high)
+		}
+	}
+}
+
+func TestSimulation(t *testing.T) {
+	draws, boards := sampleGame(t)
+	// a copy of board 0 should do exactly as well as board 0
+	twin := copyBoards(boards[:1])[0]
+	boards = append(boards, twin)
+	patterns, _ := Patterns(5, Standard...)
+	before := fmt.Sprint(boards)
+
+	run := func(workers int, seed int64) []Odds {
+		odds, err := Simulation{Trials: 500, Workers: workers, Seed: seed, Patterns: patterns}.Run(draws, boards)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return odds
+	}
+	odds := run(1, 7)
+	for _, workers := range []int{3, 0, 1000} {
+		if got := run(workers, 7); !reflect.DeepEqual(got, odds) {
+			t.Errorf("%d workers got different odds:\n%v\n%v", workers, got, odds)
+		}
+	}
+	if reflect.DeepEqual(run(2, 8), odds) {
+		t.Errorf("a different seed got the same odds")
+	}
+	if fmt.Sprint(boards) != before {
+		t.Errorf("the simulation changed the boards")
+	}
+
+	if odds[0].First != odds[3].First || odds[0].Last != odds[3].Last {
+		t.Errorf("twins have different odds: %v and %v", odds[0], odds[3])
+	}
+	first, last := 0.0, 0.0
+	for _, o := range odds {
+		first += o.First.P
+		last += o.Last.P
+	}
+	// somebody wins first in every trial, and the twins always win together
+	if first < 1 || last < 1 {
+		t.Errorf("the chances of winning first add up to %v, and last to %v", first, last)
+	}
+
+	if _, err := (Simulation{Patterns: patterns}).Run(draws, boards); err == nil {
+		t.Errorf("ran a simulation with no trials")
+	}
+}
+
+func BenchmarkDay04a(b *testing.B) {
+	bench.Solver(b, partA, "input.txt")
+}
+
+func BenchmarkDay04b(b *testing.B) {
+	bench.Solver(b, partB, "input.txt")
+}
+
+// a tournament of 10,000 boards, played through to the last winner
+func BenchmarkTournament(b *testing.B) {
+	draws, boards := randomGame(4, 10000, 5, 100)
+	patterns, _ := Patterns(5, Standard...)
+	for _, p := range []struct {
+		name string
+		play func([]int, []*Board, []Pattern) (*Board, int)
+	}{{"scan", scanLast}, {"indexed", day04b}} {
+		b.Run(p.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				game := copyBoards(boards)
+				b.StartTimer()
+				p.play(draws, game, patterns)
+			}
+		})
+	}
+}
+
+func BenchmarkSimulation(b *testing.B) {
+	draws, boards := randomGame(5, 100, 5, 100)
+	patterns, _ := Patterns(5, Standard...)
+	for i := 0; i < b.N; i++ {
+		Simulation{Trials: 1000, Seed: 1, Patterns: patterns}.Run(draws, boards)
+	}
+}
@@ -0,0 +1,81 @@
+package day04
+
+// Win is the moment a board wins.
+type Win struct {
+	Draw    int // the index of the number in the draws
+	Number  int
+	Board   int // the index of the board
+	Pattern Pattern
+	Score   int
+}
+
+// Game is the record of a game played through to the end.
+type Game struct {
+	Draws  []int
+	Boards []*Board
+	// Wins are in the order they happened; boards that win on the same
+	// draw are in board order.
+	Wins []Win
+}
+
+// Play draws the numbers in order until every board has won or there are
+// no numbers left, and records each win.
+func Play(draws []int, boards []*Board, patterns []Pattern) *Game {
+	g := &Game{Draws: draws, Boards: boards}
+	index := make(map[*Board]int, len(boards))
+	for i, b := range boards {
+		index[b] = i
+	}
+	t := NewTournament(boards, patterns)
+	for i, n := range draws {
+		if t.Remaining == 0 {
+			break
+		}
+		for _, b := range t.Draw(n) {
+			g.Wins = append(g.Wins, Win{Draw: i, Number: n, Board: index[b], Pattern: b.WonBy, Score: b.Score(n)})
+		}
+	}
+	return g
+}
+
+// Nth returns the nth win, counting from 0, or false if fewer boards won.
+func (g *Game) Nth(n int) (Win, bool) {
+	if n < 0 || n >= len(g.Wins) {
+		return Win{}, false
+	}
+	return g.Wins[n], true
+}
+
+// First returns the first win, or false if no board won.
+func (g *Game) First() (Win, bool) {
+	return g.Nth(0)
+}
+
+// Last returns the last win, or false if no board won.
+func (g *Game) Last() (Win, bool) {
+	return g.Nth(len(g.Wins) - 1)
+}
+
+// After returns the wins that had happened once k numbers were drawn.
+func (g *Game) After(k int) []Win {
+	n := 0
+	for n < len(g.Wins) && g.Wins[n].Draw < k {
+		n++
+	}
+	return g.Wins[:n]
+}
+
+// Losers returns the indexes of the boards that never won.
+func (g *Game) Losers() []int {
+	won := make([]bool, len(g.Boards))
+	for _, w := range g.Wins {
+		won[w.Board] = true
+	}
+	var result []int
+	for i := range g.Boards {
+		if !won[i] {
+			result = append(result, i)
+		}
+	}
+	return result
+}
@@ -0,0 +1,115 @@
+package day04
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Pattern is a set of squares that wins once they're all marked. Squares
+// are numbered in reading order, starting from 0.
+type Pattern struct {
+	Name  string
+	Cells []int
+}
+
+// PatternFunc makes the patterns of one kind for boards of a given size.
+type PatternFunc func(size int) ([]Pattern, error)
+
+// Standard is the rule from the puzzle: any row or column wins.
+var Standard = []PatternFunc{Rows, Columns}
+
+// Patterns collects the patterns of each kind for boards of a given size.
+func Patterns(size int, kinds ...PatternFunc) ([]Pattern, error) {
+	var result []Pattern
+	for _, kind := range kinds {
+		ps, err := kind(size)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, ps...)
+	}
+	return result, nil
+}
+
+func Rows(size int) ([]Pattern, error) {
+	var result []Pattern
+	for r := 0; r < size; r++ {
+		p := Pattern{Name: fmt.Sprintf("row %d", r+1)}
+		for c := 0; c < size; c++ {
+			p.Cells = append(p.Cells, r*size+c)
+		}
+		result = append(result, p)
+	}
+	return result, nil
+}
+
+func Columns(size int) ([]Pattern, error) {
+	var result []Pattern
+	for c := 0; c < size; c++ {
+		p := Pattern{Name: fmt.Sprintf("column %d", c+1)}
+		for r := 0; r < size; r++ {
+			p.Cells = append(p.Cells, r*size+c)
+		}
+		result = append(result, p)
+	}
+	return result, nil
+}
+
+// Diagonals are the two lines from corner to corner.
+func Diagonals(size int) ([]Pattern, error) {
+	down := Pattern{Name: "diagonal"}
+	up := Pattern{Name: "antidiagonal"}
+	for i := 0; i < size; i++ {
+		down.Cells = append(down.Cells, i*size+i)
+		up.Cells = append(up.Cells, (size-1-i)*size+i)
+	}
+	return []Pattern{down, up}, nil
+}
+
+func Corners(size int) ([]Pattern, error) {
+	if size < 2 {
+		return nil, fmt.Errorf("a board needs to be at least 2x2 to have four corners")
+	}
+	last := size*size - 1
+	return []Pattern{{Name: "corners", Cells: []int{0, size - 1, last - size + 1, last}}}, nil
+}
+
+// Blackout wins only when every square is marked.
+func Blackout(size int) ([]Pattern, error) {
+	p := Pattern{Name: "blackout"}
+	for i := 0; i < size*size; i++ {
+		p.Cells = append(p.Cells, i)
+	}
+	return []Pattern{p}, nil
+}
+
+// Mask makes a custom pattern from a picture of the board, one row per
+// line, with an X for each square in the pattern and a . for the others.
+// It only fits boards that are the same size as the picture.
+func Mask(name string, picture string) PatternFunc {
+	return func(size int) ([]Pattern, error) {
+		rows := strings.Fields(picture)
+		if len(rows) != size {
+			return nil, fmt.Errorf("mask %s has %d rows, but the boards are %dx%d", name, len(rows), size, size)
+		}
+		p := Pattern{Name: name}
+		for r, row := range rows {
+			if len(row) != size {
+				return nil, fmt.Errorf("row %d of mask %s has %d squares, but the boards are %dx%d", r+1, name, len(row), size, size)
+			}
+			for c, ch := range row {
+				switch ch {
+				case 'X', 'x':
+					p.Cells = append(p.Cells, r*size+c)
+				case '.':
+				default:
+					return nil, fmt.Errorf("row %d of mask %s has a %q; use X or .", r+1, name, ch)
+				}
+			}
+		}
+		if len(p.Cells) == 0 {
+			return nil, fmt.Errorf("mask %s doesn't have any squares", name)
+		}
+		return []Pattern{p}, nil
+	}
+}
@@ -0,0 +1,146 @@
+package day04
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"runtime"
+	"sync"
+)
+
+// Simulation estimates how likely each board is to win first, or last, if
+// the numbers were drawn in a different order.
+type Simulation struct {
+	Trials int
+	// Workers is the number of goroutines to run trials on; 0 means one
+	// for each CPU.
+	Workers  int
+	Seed     int64
+	Patterns []Pattern
+}
+
+// Estimate is a probability, along with a 95% confidence interval.
+type Estimate struct {
+	P    float64
+	Low  float64
+	High float64
+}
+
+func (e Estimate) String() string {
+	return fmt.Sprintf("%.4f (%.4f-%.4f)", e.P, e.Low, e.High)
+}
+
+// z is the number of standard deviations for 95% confidence
+const z = 1.959964
+
+// estimate uses the Wilson score interval, which behaves itself even when a
+// board hardly ever (or almost always) wins.
+func estimate(k int, n int) Estimate {
+	p := float64(k) / float64(n)
+	nf := float64(n)
+	denom := 1 + z*z/nf
+	center := (p + z*z/(2*nf)) / denom
+	half := z * math.Sqrt(p*(1-p)/nf+z*z/(4*nf*nf)) / denom
+	return Estimate{P: p, Low: math.Max(0, center-half), High: math.Min(1, center+half)}
+}
+
+// Odds are the chances of one board.
+type Odds struct {
+	Board int
+	First Estimate
+	Last  Estimate
+}
+
+// trialSeed mixes the trial number into the seed (with splitmix64), so each
+// trial shuffles the same way no matter which goroutine runs it
+func trialSeed(seed int64, trial int) int64 {
+	x := uint64(seed) + uint64(trial+1)*0x9e3779b97f4a7c15
+	x = (x ^ (x >> 30)) * 0xbf58476d1ce4e5b9
+	x = (x ^ (x >> 27)) * 0x94d049bb133111eb
+	return int64(x ^ (x >> 31))
+}
+
+// Run shuffles the draws for each trial and plays the boards, which aren't
+// changed. Boards that win on the same draw as the first winner all count
+// as winning first, and likewise for last; so the chances can add up to
+// more than 1.
+func (s Simulation) Run(draws []int, boards []*Board) ([]Odds, error) {
+	if s.Trials < 1 {
+		return nil, fmt.Errorf("a simulation needs at least one trial, not %d", s.Trials)
+	}
+	workers := s.Workers
+	if workers < 1 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if workers > s.Trials {
+		workers = s.Trials
+	}
+
+	firsts := make([][]int, workers)
+	lasts := make([][]int, workers)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		firsts[w] = make([]int, len(boards))
+		lasts[w] = make([]int, len(boards))
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			s.work(w, workers, draws, boards, firsts[w], lasts[w])
+		}(w)
+	}
+	wg.Wait()
+
+	odds := make([]Odds, len(boards))
+	for b := range boards {
+		first, last := 0, 0
+		for w := 0; w < workers; w++ {
+			first += firsts[w][b]
+			last += lasts[w][b]
+		}
+		odds[b] = Odds{Board: b, First: estimate(first, s.Trials), Last: estimate(last, s.Trials)}
+	}
+	return odds, nil
+}
+
+// work runs every workers'th trial, starting with trial w, on its own copy
+// of the boards, counting the first and last winners
+func (s Simulation) work(w int, workers int, draws []int, boards []*Board, firsts []int, lasts []int) {
+	mine := make([]*Board, len(boards))
+	index := make(map[*Board]int, len(boards))
+	for i, b := range boards {
+		c := *b
+		c.Squares = append([]Square(nil), b.Squares...)
+		mine[i] = &c
+		index[&c] = i
+	}
+	t := NewTournament(mine, s.Patterns)
+	shuffled := make([]int, len(draws))
+	for trial := w; trial < s.Trials; trial += workers {
+		copy(shuffled, draws)
+		rnd := rand.New(rand.NewSource(trialSeed(s.Seed, trial)))
+		rnd.Shuffle(len(shuffled), func(i, j int) {
+			shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
+		})
+
+		t.Reset()
+		var last []*Board
+		for _, n := range shuffled {
+			if t.Remaining == 0 {
+				break
+			}
+			winners := t.Draw(n)
+			if len(winners) == 0 {
+				continue
+			}
+			if last == nil {
+				for _, b := range winners {
+					firsts[index[b]]++
+				}
+			}
+			last = winners
+		}
+		for _, b := range last {
+			lasts[index[b]]++
+		}
+	}
+}
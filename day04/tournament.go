@@ -0,0 +1,109 @@
+package day04
+
+// Tournament plays a lot of boards at once. Instead of looking through every
+// board for each number that's drawn, it keeps an index of where each number
+// is, and counts how many squares of each pattern are marked on each board,
+// so a draw only touches the squares with that number on them.
+type Tournament struct {
+	Boards   []*Board
+	Patterns []Pattern
+	// Remaining is the number of boards that haven't won yet.
+	Remaining int
+
+	where map[int][]location
+	// inPatterns lists the patterns that each square is in
+	inPatterns [][]int
+	// marks counts the marked squares of pattern p on board b, at
+	// b*len(Patterns)+p
+	marks []int
+}
+
+// location is a square on a board
+type location struct {
+	board int
+	cell  int
+}
+
+// NewTournament indexes boards, which must all be the same size, for
+// playing with the given patterns. Squares that are already marked count.
+func NewTournament(boards []*Board, patterns []Pattern) *Tournament {
+	t := &Tournament{
+		Boards:    boards,
+		Patterns:  patterns,
+		where:     make(map[int][]location),
+		marks:     make([]int, len(boards)*len(patterns)),
+		Remaining: len(boards),
+	}
+	if len(boards) == 0 {
+		return t
+	}
+	t.inPatterns = make([][]int, len(boards[0].Squares))
+	for p, pattern := range patterns {
+		for _, cell := range pattern.Cells {
+			t.inPatterns[cell] = append(t.inPatterns[cell], p)
+		}
+	}
+	for b, board := range boards {
+		if board.Win {
+			t.Remaining--
+		}
+		for cell, sq := range board.Squares {
+			// like MarkMatching, only the first square with a number counts
+			locs := t.where[sq.Value]
+			if len(locs) > 0 && locs[len(locs)-1].board == b {
+				continue
+			}
+			t.where[sq.Value] = append(locs, location{board: b, cell: cell})
+			if sq.Marked {
+				for _, p := range t.inPatterns[cell] {
+					t.marks[b*len(patterns)+p]++
+				}
+			}
+		}
+	}
+	return t
+}
+
+// Draw marks a number on every board that hasn't won yet, and returns the
+// boards that win because of it, in order. As with CheckForWin, when a
+// number completes more than one pattern, the first one is the one that won.
+func (t *Tournament) Draw(n int) []*Board {
+	var winners []*Board
+	for _, loc := range t.where[n] {
+		board := t.Boards[loc.board]
+		if board.Win || board.Squares[loc.cell].Marked {
+			continue
+		}
+		board.Squares[loc.cell].Marked = true
+		won := -1
+		for _, p := range t.inPatterns[loc.cell] {
+			i := loc.board*len(t.Patterns) + p
+			t.marks[i]++
+			if t.marks[i] == len(t.Patterns[p].Cells) && (won == -1 || p < won) {
+				won = p
+			}
+		}
+		if won >= 0 {
+			board.Win = true
+			board.WonBy = t.Patterns[won]
+			t.Remaining--
+			winners = append(winners, board)
+		}
+	}
+	return winners
+}
+
+// Reset clears every board, so the tournament can be played again.
+func (t *Tournament) Reset() {
+	for _, b := range t.Boards {
+		for i := range b.Squares {
+			b.Squares[i].Marked = false
+		}
+		b.Win = false
+		b.WonBy = Pattern{}
+	}
+	for i := range t.marks {
+		t.marks[i] = 0
+	}
+	t.Remaining = len(t.Boards)
+}
@@ -0,0 +1,136 @@
+package day05
+
+import (
+	"regexp"
+	"strconv"
+
+	"github.com/kentquirk/aoc2021/geometry"
+	"github.com/kentquirk/aoc2021/input"
+	"github.com/kentquirk/aoc2021/registry"
+)
+
+type Line struct {
+	P1 geometry.Point
+	P2 geometry.Point
+}
+
+func NewLine(p1 geometry.Point, p2 geometry.Point) *Line {
+	if p1.Less(p2) {
+		return &Line{P1: p1, P2: p2}
+	}
+	return &Line{P1: p2, P2: p1}
+}
+
+func (l Line) IsHorizontal() bool {
+	return l.P1.Y == l.P2.Y
+}
+
+func (l Line) IsVertical() bool {
+	return l.P1.X == l.P2.X
+}
+
+func (l Line) IsDiagonal() bool {
+	return l.P1.X != l.P2.X && l.P1.Y != l.P2.Y
+}
+
+func (l Line) DrawHV(grid map[geometry.Point]int) {
+	if !l.IsDiagonal() {
+		l.Draw(grid)
+	}
+}
+
+// Draw marks each point on a horizontal, vertical or 45° line
+func (l Line) Draw(grid map[geometry.Point]int) {
+	step := l.P1.VectorTo(l.P2).Sign()
+	for p := l.P1; p != l.P2; p = p.Add(step) {
+		grid[p]++
+	}
+	grid[l.P2]++
+}
+
+var linePat = regexp.MustCompile("^([0-9]+),([0-9]+) -> ([0-9]+),([0-9]+)$")
+
+func parseLine(s string) (*Line, error) {
+	ixs := linePat.FindStringSubmatchIndex(s)
+	if ixs == nil {
+		return nil, input.Errorf(1, 0, s, "expected a line like '0,9 -> 5,9'")
+	}
+	var coords [4]int
+	for i := range coords {
+		lo, hi := ixs[2*i+2], ixs[2*i+3]
+		n, err := strconv.Atoi(s[lo:hi])
+		if err != nil {
+			return nil, input.Errorf(1, lo+1, s[lo:hi], "coordinate out of range")
+		}
+		coords[i] = n
+	}
+	l := NewLine(
+		geometry.Point{X: coords[0], Y: coords[1]},
+		geometry.Point{X: coords[2], Y: coords[3]},
+	)
+	if v := l.P1.VectorTo(l.P2); l.IsDiagonal() && geometry.Abs(v.X) != geometry.Abs(v.Y) {
+		return nil, input.Errorf(1, 0, s, "lines must be horizontal, vertical or at 45 degrees")
+	}
+	return l, nil
+}
+
+func parseLines(text []string) ([]*Line, error) {
+	var lines []*Line
+	for i, t := range text {
+		l, err := parseLine(t)
+		if err != nil {
+			return nil, input.Offset(err, i+1)
+		}
+		lines = append(lines, l)
+	}
+	return lines, nil
+}
+
+// drawOverlaps counts the overlaps the slow way, by drawing every point of
+// every line
+func drawOverlaps(lines []*Line, diagonals bool) int {
+	grid := make(map[geometry.Point]int)
+	for _, l := range lines {
+		if diagonals {
+			l.Draw(grid)
+		} else {
+			l.DrawHV(grid)
+		}
+	}
+
+	count := 0
+	for _, v := range grid {
+		if v > 1 {
+			count++
+		}
+	}
+	return count
+}
+
+func day05a(lines []*Line) int {
+	return CountOverlaps(lines, false)
+}
+
+func day05b(lines []*Line) int {
+	return CountOverlaps(lines, true)
+}
+
+func partA(in *input.Input) (interface{}, error) {
+	lines, err := parseLines(in.Lines())
+	if err != nil {
+		return nil, err
+	}
+	return day05a(lines), nil
+}
+
+func partB(in *input.Input) (interface{}, error) {
+	lines, err := parseLines(in.Lines())
+	if err != nil {
+		return nil, err
+	}
+	return day05b(lines), nil
+}
+
+func init() {
+	registry.Register(5, partA, partB)
+}
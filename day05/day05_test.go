@@ -0,0 +1,118 @@
+package day05
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/kentquirk/aoc2021/bench"
+	"github.com/kentquirk/aoc2021/geometry"
+)
+
+func line(x1, y1, x2, y2 int) *Line {
+	return NewLine(geometry.Point{X: x1, Y: y1}, geometry.Point{X: x2, Y: y2})
+}
+
+// randomLines makes n lines in every direction, with their ends between lo
+// and lo+size, and some of them on top of the lines before them
+func randomLines(seed int64, n int, lo int, size int, maxLen int) []*Line {
+	rnd := rand.New(rand.NewSource(seed))
+	dirs := []geometry.Point{{X: 1}, {Y: 1}, {X: 1, Y: 1}, {X: 1, Y: -1}, {X: -1}, {Y: -1}, {X: -1, Y: -1}, {X: -1, Y: 1}}
+	var lines []*Line
+	for len(lines) < n {
+		start := geometry.Point{X: lo + rnd.Intn(size), Y: lo + rnd.Intn(size)}
+		dir := dirs[rnd.Intn(len(dirs))]
+		if len(lines) > 0 && rnd.Intn(4) == 0 {
+			// start somewhere along an earlier line, going the same way
+			prev := lines[rnd.Intn(len(lines))]
+			dir = prev.P1.VectorTo(prev.P2).Sign()
+			start = prev.P1.Add(dir.Scale(rnd.Intn(maxLen)))
+		}
+		end := start.Add(dir.Scale(rnd.Intn(maxLen)))
+		lines = append(lines, NewLine(start, end))
+	}
+	return lines
+}
+
+func TestCountOverlaps(t *testing.T) {
+	tests := []struct {
+		name  string
+		lines []*Line
+		want  int
+	}{
+		{"nothing", nil, 0},
+		{"a point twice", []*Line{line(3, 3, 3, 3), line(3, 3, 3, 3)}, 1},
+		{"a point on a line", []*Line{line(3, 3, 3, 3), line(0, 0, 5, 5)}, 1},
+		{"collinear", []*Line{line(0, 0, 5, 0), line(3, 0, 9, 0), line(9, 0, 12, 0)}, 4},
+		{"three deep", []*Line{line(0, 0, 4, 0), line(0, 0, 4, 0), line(0, 0, 4, 0)}, 5},
+		{"cross", []*Line{line(0, 2, 4, 2), line(2, 0, 2, 4)}, 1},
+		{"x", []*Line{line(0, 0, 4, 4), line(0, 4, 4, 0)}, 1},
+		{"x between points", []*Line{line(0, 0, 3, 3), line(0, 3, 3, 0)}, 0},
+		{"star", []*Line{line(0, 2, 4, 2), line(2, 0, 2, 4), line(0, 0, 4, 4), line(0, 4, 4, 0)}, 1},
+		{"overlaps that cross", []*Line{line(0, 2, 4, 2), line(0, 2, 4, 2), line(2, 0, 2, 4), line(2, 0, 2, 4)}, 9},
+		{"end to end", []*Line{line(0, 0, 2, 2), line(2, 2, 4, 0)}, 1},
+		{"negative", []*Line{line(-5, -5, 5, 5), line(-5, 5, 5, -5), line(-9, 0, 0, 0)}, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CountOverlaps(tt.lines, true); got != tt.want {
+				t.Errorf("CountOverlaps() = %d, want %d", got, tt.want)
+			}
+			if got := drawOverlaps(tt.lines, true); got != tt.want {
+				t.Errorf("drawOverlaps() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+
+	for seed := int64(1); seed <= 50; seed++ {
+		lines := randomLines(seed, 5+int(seed)*2, -10, 30, 25)
+		for _, diagonals := range []bool{false, true} {
+			t.Run(fmt.Sprintf("seed %d, diagonals %v", seed, diagonals), func(t *testing.T) {
+				if got, want := CountOverlaps(lines, diagonals), drawOverlaps(lines, diagonals); got != want {
+					t.Errorf("CountOverlaps() = %d, but drawing them gets %d", got, want)
+				}
+			})
+		}
+	}
+}
+
+func TestParseLine(t *testing.T) {
+	tests := []struct {
+		text    string
+		wantErr bool
+	}{
+		{"0,9 -> 5,9", false},
+		{"8,0 -> 0,8", false},
+		{"1,1 -> 1,1", false},
+		{"0,0 -> 2,1", true},
+		{"0,0 -> 2", true},
+	}
+	for _, tt := range tests {
+		if _, err := parseLine(tt.text); (err != nil) != tt.wantErr {
+			t.Errorf("parseLine(%q) error = %v, wantErr %v", tt.text, err, tt.wantErr)
+		}
+	}
+}
+
+func BenchmarkDay05a(b *testing.B) {
+	bench.Solver(b, partA, "input.txt")
+}
+
+func BenchmarkDay05b(b *testing.B) {
+	bench.Solver(b, partB, "input.txt")
+}
+
+// long lines, with coordinates in the millions
+func BenchmarkOverlaps(b *testing.B) {
+	lines := randomLines(5, 300, 1000000, 100000, 50000)
+	for _, m := range []struct {
+		name  string
+		count func([]*Line, bool) int
+	}{{"draw", drawOverlaps}, {"sweep", CountOverlaps}} {
+		b.Run(m.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				m.count(lines, true)
+			}
+		})
+	}
+}
@@ -0,0 +1,202 @@
+package day05
+
+import (
+	"sort"
+
+	"github.com/kentquirk/aoc2021/geometry"
+)
+
+// Counting the overlaps without drawing: every segment lies on a track,
+// which is a row, a column, or one of the diagonals. Sweeping along each
+// track finds the stretches that one or more segments cover, and the ones
+// that two or more do. Tracks of the same kind are parallel, and tracks of
+// different kinds cross at no more than one point, so the only other points
+// that can be covered twice are where tracks cross.
+
+// the kinds of tracks
+const (
+	horizontal = iota
+	vertical
+	diagonal     // x-y is the same all along it
+	antidiagonal // x+y is the same all along it
+	nkinds
+)
+
+// span is a stretch of a track, inclusive
+type span struct {
+	lo int
+	hi int
+}
+
+type track struct {
+	segments   []span
+	covered    []span // by at least one segment, in order
+	overlapped []span // by at least two segments, in order
+}
+
+// kind returns the kind of track a line is on; a line that's just a point
+// counts as horizontal
+func (l Line) kind() int {
+	switch {
+	case l.IsHorizontal():
+		return horizontal
+	case l.IsVertical():
+		return vertical
+	case l.P2.Y > l.P1.Y:
+		return diagonal
+	}
+	return antidiagonal
+}
+
+// key identifies the track of a kind that a point is on
+func key(kind int, p geometry.Point) int {
+	switch kind {
+	case horizontal:
+		return p.Y
+	case vertical:
+		return p.X
+	case diagonal:
+		return p.X - p.Y
+	}
+	return p.X + p.Y
+}
+
+// along returns how far along its track a point is
+func along(kind int, p geometry.Point) int {
+	if kind == vertical {
+		return p.Y
+	}
+	return p.X
+}
+
+// crossing returns the point where two tracks of different kinds meet, if
+// it's a whole point; k1 is before k2 in the list of kinds
+func crossing(kind1 int, k1 int, kind2 int, k2 int) (geometry.Point, bool) {
+	switch {
+	case kind1 == horizontal && kind2 == vertical:
+		return geometry.Point{X: k2, Y: k1}, true
+	case kind1 == horizontal && kind2 == diagonal:
+		return geometry.Point{X: k2 + k1, Y: k1}, true
+	case kind1 == horizontal && kind2 == antidiagonal:
+		return geometry.Point{X: k2 - k1, Y: k1}, true
+	case kind1 == vertical && kind2 == diagonal:
+		return geometry.Point{X: k1, Y: k1 - k2}, true
+	case kind1 == vertical && kind2 == antidiagonal:
+		return geometry.Point{X: k1, Y: k2 - k1}, true
+	}
+	// the diagonals only meet at a whole point if their keys are both odd
+	// or both even
+	if (k1+k2)%2 != 0 {
+		return geometry.Point{}, false
+	}
+	return geometry.Point{X: (k1 + k2) / 2, Y: (k2 - k1) / 2}, true
+}
+
+// sweep finds the stretches covered by one or more, and two or more, of
+// the segments on a track
+func (t *track) sweep() {
+	type event struct {
+		at    int
+		delta int
+	}
+	events := make([]event, 0, 2*len(t.segments))
+	for _, s := range t.segments {
+		events = append(events, event{at: s.lo, delta: 1}, event{at: s.hi + 1, delta: -1})
+	}
+	sort.Slice(events, func(i, j int) bool { return events[i].at < events[j].at })
+
+	count := 0
+	for i := 0; i < len(events); {
+		at, before := events[i].at, count
+		for ; i < len(events) && events[i].at == at; i++ {
+			count += events[i].delta
+		}
+		t.covered = transition(t.covered, before, count, 1, at)
+		t.overlapped = transition(t.overlapped, before, count, 2, at)
+	}
+}
+
+// transition starts or ends a span when the count crosses the threshold
+func transition(spans []span, before int, after int, threshold int, at int) []span {
+	switch {
+	case before < threshold && after >= threshold:
+		spans = append(spans, span{lo: at})
+	case before >= threshold && after < threshold:
+		spans[len(spans)-1].hi = at - 1
+	}
+	return spans
+}
+
+// contains says whether any of the spans, which are in order, includes n
+func contains(spans []span, n int) bool {
+	i := sort.Search(len(spans), func(i int) bool { return spans[i].hi >= n })
+	return i < len(spans) && spans[i].lo <= n
+}
+
+// CountOverlaps counts the points where at least two lines overlap, working
+// from the ends of the lines rather than every point on them. Lines must be
+// horizontal, vertical or at 45°; diagonal ones are skipped unless
+// diagonals is true.
+func CountOverlaps(lines []*Line, diagonals bool) int {
+	var tracks [nkinds]map[int]*track
+	for k := range tracks {
+		tracks[k] = make(map[int]*track)
+	}
+	for _, l := range lines {
+		if l.IsDiagonal() && !diagonals {
+			continue
+		}
+		k := l.kind()
+		id := key(k, l.P1)
+		t := tracks[k][id]
+		if t == nil {
+			t = new(track)
+			tracks[k][id] = t
+		}
+		// P1 comes first, so it's the low end along every kind of track
+		t.segments = append(t.segments, span{lo: along(k, l.P1), hi: along(k, l.P2)})
+	}
+
+	count := 0
+	for k := range tracks {
+		for _, t := range tracks[k] {
+			t.sweep()
+			for _, s := range t.overlapped {
+				count += s.hi - s.lo + 1
+			}
+		}
+	}
+
+	// find the crossings that are covered on both tracks
+	crossings := make(map[geometry.Point]bool)
+	for k1 := 0; k1 < nkinds; k1++ {
+		for k2 := k1 + 1; k2 < nkinds; k2++ {
+			for id1, t1 := range tracks[k1] {
+				for id2, t2 := range tracks[k2] {
+					p, ok := crossing(k1, id1, k2, id2)
+					if ok && contains(t1.covered, along(k1, p)) && contains(t2.covered, along(k2, p)) {
+						crossings[p] = true
+					}
+				}
+			}
+		}
+	}
+
+	// a crossing that's not already overlapped on one of its tracks is a
+	// new overlap; one that's overlapped on more than one has been counted
+	// too many times
+	for p := range crossings {
+		overlapped := 0
+		for k := range tracks {
+			if t := tracks[k][key(k, p)]; t != nil && contains(t.overlapped, along(k, p)) {
+				overlapped++
+			}
+		}
+		if overlapped == 0 {
+			count++
+		} else {
+			count -= overlapped - 1
+		}
+	}
+	return count
+}
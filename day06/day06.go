@@ -0,0 +1,66 @@
+package day06
+
+import (
+	"github.com/kentquirk/aoc2021/input"
+	"github.com/kentquirk/aoc2021/registry"
+)
+
+// simulate tracks the number of fish at each timer value rather than
+// the individual fish, so it's fast for any number of days.
+func simulate(states []int, numDays int) int {
+	ocean := make(map[int]int)
+	for _, s := range states {
+		ocean[s]++
+	}
+
+	for i := 1; i <= numDays; i++ {
+		for days := 8; days >= 0; days-- {
+			count := ocean[days]
+			if count == 0 {
+				continue
+			}
+			if days == 0 {
+				ocean[7] += count
+				ocean[9] = count
+			}
+		}
+		for days := 0; days <= 9; days++ {
+			ocean[days] = ocean[days+1]
+		}
+		// fmt.Println(ocean)
+	}
+
+	total := 0
+	for days := 0; days < 9; days++ {
+		total += ocean[days]
+	}
+	return total
+}
+
+func day06a(states []int) int {
+	return simulate(states, 80)
+}
+
+func day06b(states []int) int {
+	return simulate(states, 256)
+}
+
+func partA(in *input.Input) (interface{}, error) {
+	states, err := in.Ints()
+	if err != nil {
+		return nil, err
+	}
+	return day06a(states), nil
+}
+
+func partB(in *input.Input) (interface{}, error) {
+	states, err := in.Ints()
+	if err != nil {
+		return nil, err
+	}
+	return day06b(states), nil
+}
+
+func init() {
+	registry.Register(6, partA, partB)
+}
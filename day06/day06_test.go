@@ -0,0 +1,15 @@
+package day06
+
+import (
+	"testing"
+
+	"github.com/kentquirk/aoc2021/bench"
+)
+
+func BenchmarkDay06a(b *testing.B) {
+	bench.Solver(b, partA, "input.txt")
+}
+
+func BenchmarkDay06b(b *testing.B) {
+	bench.Solver(b, partB, "input.txt")
+}
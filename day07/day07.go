@@ -1,13 +1,11 @@
-package main
+package day07
 
 import (
-	"fmt"
-	"io/ioutil"
-	"log"
-	"os"
 	"sort"
-	"strconv"
-	"strings"
+
+	"github.com/kentquirk/aoc2021/debug"
+	"github.com/kentquirk/aoc2021/input"
+	"github.com/kentquirk/aoc2021/registry"
 )
 
 func day07a(crabs []int) int {
@@ -18,9 +16,7 @@ func day07a(crabs []int) int {
 	ix := len(crabs) / 2
 	pos := crabs[ix]
 
-	fmt.Println(getError(crabs, pos))
-
-	return pos
+	return getError(crabs, pos)
 }
 
 func getError(crabs []int, pos int) int {
@@ -46,14 +42,14 @@ func day07b(crabs []int) int {
 
 	for {
 		fuel := getError2(crabs, pos)
-		fmt.Printf("trying %d: fuel=%d\n", pos, fuel)
+		debug.Printf("trying %d: fuel=%d\n", pos, fuel)
 
 		fuel1 := getError2(crabs, pos+1)
 		fuel2 := getError2(crabs, pos-1)
 
 		if fuel1 > fuel && fuel2 > fuel {
 			// we've found the optimum
-			fmt.Printf("found %d: fuel=%d\n", pos, fuel)
+			debug.Printf("found %d: fuel=%d\n", pos, fuel)
 			return fuel
 		} else if fuel1 < fuel {
 			// increase pos
@@ -83,22 +79,22 @@ func getError2(crabs []int, pos int) int {
 	return total
 }
 
-func main() {
-	f, err := os.Open("./lqinput.txt")
+func partA(in *input.Input) (interface{}, error) {
+	crabs, err := in.Ints()
 	if err != nil {
-		log.Fatal(err)
+		return nil, err
 	}
-	b, err := ioutil.ReadAll(f)
+	return day07a(crabs), nil
+}
+
+func partB(in *input.Input) (interface{}, error) {
+	crabs, err := in.Ints()
 	if err != nil {
-		log.Fatal(err)
+		return nil, err
 	}
-	s := strings.Trim(string(b), " \n\t")
-	nums := strings.Split(s, ",")
-	var crabs []int
-	for _, n := range nums {
-		v, _ := strconv.Atoi(n)
-		crabs = append(crabs, v)
-	}
-	fmt.Println(day07a(crabs))
-	fmt.Println(day07b(crabs))
+	return day07b(crabs), nil
+}
+
+func init() {
+	registry.Register(7, partA, partB)
 }
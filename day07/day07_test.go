@@ -0,0 +1,15 @@
+package day07
+
+import (
+	"testing"
+
+	"github.com/kentquirk/aoc2021/bench"
+)
+
+func BenchmarkDay07a(b *testing.B) {
+	bench.Solver(b, partA, "input.txt")
+}
+
+func BenchmarkDay07b(b *testing.B) {
+	bench.Solver(b, partB, "input.txt")
+}
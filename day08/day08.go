@@ -1,13 +1,11 @@
-package main
+package day08
 
 import (
-	"fmt"
-	"io/ioutil"
-	"log"
-	"os"
 	"regexp"
 	"strings"
 
+	"github.com/kentquirk/aoc2021/input"
+	"github.com/kentquirk/aoc2021/registry"
 	"github.com/kentquirk/stringset/v2"
 )
 
@@ -18,14 +16,33 @@ type Pattern struct {
 	Digits    []int
 }
 
-func NewPattern(s string) *Pattern {
-	parts := strings.Split(s, "|")
-	r := regexp.MustCompile("[a-g]+")
-	return &Pattern{
-		Input:  r.FindAllString(parts[0], -1),
-		Output: r.FindAllString(parts[1], -1),
-		Digits: make([]int, 0),
+var (
+	wordPat    = regexp.MustCompile("[^ |]+")
+	segmentPat = regexp.MustCompile("^[a-g]{2,7}$")
+)
+
+func NewPattern(s string) (*Pattern, error) {
+	bar := strings.Index(s, "|")
+	if bar == -1 {
+		return nil, input.Errorf(1, 0, s, "expected ten patterns, '|', and four output digits")
+	}
+	pat := &Pattern{Digits: make([]int, 0)}
+	for _, ix := range wordPat.FindAllStringIndex(s, -1) {
+		w := s[ix[0]:ix[1]]
+		if !segmentPat.MatchString(w) {
+			return nil, input.Errorf(1, ix[0]+1, w, "a pattern must be 2 to 7 of the segments a-g")
+		}
+		if ix[0] < bar {
+			pat.Input = append(pat.Input, w)
+		} else {
+			pat.Output = append(pat.Output, w)
+		}
 	}
+	if len(pat.Input) != 10 || len(pat.Output) != 4 {
+		return nil, input.Errorf(1, 0, s, "expected ten patterns and four output digits, found %d and %d",
+			len(pat.Input), len(pat.Output))
+	}
+	return pat, nil
 }
 
 func setFromString(s string) *stringset.StringSet {
@@ -190,20 +207,34 @@ func day08b(pats []*Pattern) int {
 	return total
 }
 
-func main() {
-	f, err := os.Open("./input.txt")
-	if err != nil {
-		log.Fatal(err)
+func parseInput(in *input.Input) ([]*Pattern, error) {
+	var pats []*Pattern
+	for i, l := range in.Lines() {
+		p, err := NewPattern(l)
+		if err != nil {
+			return nil, input.Offset(err, i+1)
+		}
+		pats = append(pats, p)
 	}
-	b, err := ioutil.ReadAll(f)
+	return pats, nil
+}
+
+func partA(in *input.Input) (interface{}, error) {
+	pats, err := parseInput(in)
 	if err != nil {
-		log.Fatal(err)
+		return nil, err
 	}
-	lines := strings.Split(string(b), "\n")
-	var pats []*Pattern
-	for _, l := range lines {
-		pats = append(pats, NewPattern(l))
+	return day08a(pats), nil
+}
+
+func partB(in *input.Input) (interface{}, error) {
+	pats, err := parseInput(in)
+	if err != nil {
+		return nil, err
 	}
-	fmt.Println(day08a(pats))
-	fmt.Println(day08b(pats))
+	return day08b(pats), nil
+}
+
+func init() {
+	registry.Register(8, partA, partB)
 }
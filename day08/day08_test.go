@@ -0,0 +1,15 @@
+package day08
+
+import (
+	"testing"
+
+	"github.com/kentquirk/aoc2021/bench"
+)
+
+func BenchmarkDay08a(b *testing.B) {
+	bench.Solver(b, partA, "input.txt")
+}
+
+func BenchmarkDay08b(b *testing.B) {
+	bench.Solver(b, partB, "input.txt")
+}
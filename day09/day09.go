@@ -0,0 +1,74 @@
+package day09
+
+import (
+	"sort"
+
+	"github.com/kentquirk/aoc2021/grid"
+	"github.com/kentquirk/aoc2021/input"
+	"github.com/kentquirk/aoc2021/registry"
+)
+
+func day09a(heightmap *grid.Dense[int]) int {
+	risk := 0
+	var lowpoints []grid.Point
+	heightmap.Each(func(p grid.Point, height int) {
+		for _, n := range grid.Neighbors[int](heightmap, p, grid.Orthogonal) {
+			if height >= heightmap.Get(n) {
+				return
+			}
+		}
+		lowpoints = append(lowpoints, p)
+		risk += height + 1
+	})
+	// fmt.Println(lowpoints)
+	return risk
+}
+
+func floodfill(floor *grid.Dense[int], p grid.Point, index int) int {
+	if !floor.InBounds(p) || floor.Get(p) >= 9 {
+		return 0
+	}
+	floor.Set(p, index)
+	size := 1
+	for _, n := range grid.Neighbors[int](floor, p, grid.Orthogonal) {
+		size += floodfill(floor, n, index)
+	}
+	return size
+}
+
+// the second half is just a floodfill problem, so we'll do an inefficient recursive floodfill
+func day09b(heightmap *grid.Dense[int]) int {
+	floor := heightmap.Clone()
+
+	var basins []int
+	index := 10
+	floor.Each(func(p grid.Point, _ int) {
+		size := floodfill(floor, p, index)
+		if size != 0 {
+			basins = append(basins, size)
+			index++
+		}
+	})
+	sort.Ints(basins)
+	return basins[len(basins)-1] * basins[len(basins)-2] * basins[len(basins)-3]
+}
+
+func partA(in *input.Input) (interface{}, error) {
+	heightmap, err := grid.ParseDigits(in.Text)
+	if err != nil {
+		return nil, err
+	}
+	return day09a(heightmap), nil
+}
+
+func partB(in *input.Input) (interface{}, error) {
+	heightmap, err := grid.ParseDigits(in.Text)
+	if err != nil {
+		return nil, err
+	}
+	return day09b(heightmap), nil
+}
+
+func init() {
+	registry.Register(9, partA, partB)
+}
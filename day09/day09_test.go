@@ -0,0 +1,15 @@
+package day09
+
+import (
+	"testing"
+
+	"github.com/kentquirk/aoc2021/bench"
+)
+
+func BenchmarkDay09a(b *testing.B) {
+	bench.Solver(b, partA, "input.txt")
+}
+
+func BenchmarkDay09b(b *testing.B) {
+	bench.Solver(b, partB, "input.txt")
+}
@@ -0,0 +1,95 @@
+package day10
+
+import (
+	"sort"
+
+	"github.com/kentquirk/aoc2021/input"
+	"github.com/kentquirk/aoc2021/registry"
+)
+
+func parse(s string) (int, int, error) {
+	errscores := map[rune]int{
+		')': 3,
+		']': 57,
+		'}': 1197,
+		'>': 25137,
+	}
+	fixscores := map[rune]int{
+		')': 1,
+		']': 2,
+		'}': 3,
+		'>': 4,
+	}
+
+	var expected []rune
+	for i, r := range s {
+		switch r {
+		case '(':
+			expected = append(expected, ')')
+		case '<':
+			expected = append(expected, '>')
+		case '[':
+			expected = append(expected, ']')
+		case '{':
+			expected = append(expected, '}')
+		case '}', ')', ']', '>':
+			if len(expected) == 0 {
+				return 0, 0, input.Errorf(1, i+1, string(r), "closing bracket with nothing open")
+			}
+			want := expected[len(expected)-1]
+			expected = expected[:len(expected)-1]
+			if want != r {
+				return errscores[r], 0, nil
+			}
+		default:
+			return 0, 0, input.Errorf(1, i+1, string(r), "not a bracket")
+		}
+	}
+
+	fixscore := 0
+	for i := len(expected) - 1; i >= 0; i-- {
+		fixscore = 5*fixscore + fixscores[expected[i]]
+	}
+
+	return 0, fixscore, nil
+}
+
+func day10a(lines []string) (int, error) {
+	errscore := 0
+	for i, l := range lines {
+		e, _, err := parse(l)
+		if err != nil {
+			return 0, input.Offset(err, i+1)
+		}
+		errscore += e
+	}
+	return errscore, nil
+}
+
+func day10b(lines []string) (int, error) {
+	var fixscores []int
+	for i, l := range lines {
+		_, fixscore, err := parse(l)
+		if err != nil {
+			return 0, input.Offset(err, i+1)
+		}
+		if fixscore != 0 {
+			fixscores = append(fixscores, fixscore)
+		}
+	}
+
+	sort.Ints(fixscores)
+	return fixscores[len(fixscores)/2], nil
+}
+
+func partA(in *input.Input) (interface{}, error) {
+	return day10a(in.Lines())
+}
+
+func partB(in *input.Input) (interface{}, error) {
+	return day10b(in.Lines())
+}
+
+func init() {
+	registry.Register(10, partA, partB)
+}
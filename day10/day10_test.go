@@ -0,0 +1,15 @@
+package day10
+
+import (
+	"testing"
+
+	"github.com/kentquirk/aoc2021/bench"
+)
+
+func BenchmarkDay10a(b *testing.B) {
+	bench.Solver(b, partA, "input.txt")
+}
+
+func BenchmarkDay10b(b *testing.B) {
+	bench.Solver(b, partB, "input.txt")
+}
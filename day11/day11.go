@@ -0,0 +1,142 @@
+package day11
+
+import (
+	"strconv"
+
+	"github.com/kentquirk/aoc2021/debug"
+	"github.com/kentquirk/aoc2021/grid"
+	"github.com/kentquirk/aoc2021/input"
+	"github.com/kentquirk/aoc2021/registry"
+)
+
+type Octopus struct {
+	Energy  int
+	Flashed bool
+}
+
+func NewOctopus(state int) *Octopus {
+	return &Octopus{
+		Energy: state,
+	}
+}
+
+func (o *Octopus) Step() {
+	o.Flashed = false
+	o.Energy++
+}
+
+func (o *Octopus) Nudge() {
+	if !o.Flashed {
+		o.Energy++
+	}
+}
+
+type OctopusGarden struct {
+	Octopuses *grid.Dense[*Octopus]
+	Score     int
+}
+
+func NewOctopusGarden(octomap *grid.Dense[int]) *OctopusGarden {
+	octopuses := grid.NewDense[*Octopus](octomap.Width(), octomap.Height())
+	octomap.Each(func(p grid.Point, energy int) {
+		octopuses.Set(p, NewOctopus(energy))
+	})
+	return &OctopusGarden{Octopuses: octopuses}
+}
+
+func (g *OctopusGarden) Step() {
+	g.Octopuses.Each(func(_ grid.Point, oct *Octopus) {
+		oct.Step()
+	})
+}
+
+// MaybeFlash flashes every octopus with enough energy, which nudges all of
+// its neighbors, and returns the number that flashed
+func (g *OctopusGarden) MaybeFlash() int {
+	score := 0
+	g.Octopuses.Each(func(p grid.Point, oct *Octopus) {
+		if oct.Energy <= 9 {
+			return
+		}
+		oct.Flashed = true
+		oct.Energy = 0
+		for _, n := range grid.Neighbors[*Octopus](g.Octopuses, p, grid.Adjacent) {
+			g.Octopuses.Get(n).Nudge()
+		}
+		score++
+	})
+	return score
+}
+
+func (g *OctopusGarden) Print() {
+	debug.Print(grid.Format[*Octopus](g.Octopuses, func(oct *Octopus) string {
+		return strconv.Itoa(oct.Energy)
+	}))
+}
+
+func day11a(octomap *grid.Dense[int]) int {
+	const nSteps = 100
+	var score int
+	octopuses := NewOctopusGarden(octomap)
+
+	// octopuses.Print()
+
+	for i := 1; i <= nSteps; i++ {
+		octopuses.Step()
+		n := 1
+		for n != 0 {
+			n = octopuses.MaybeFlash()
+			score += n
+			// fmt.Println(n)
+		}
+		// fmt.Printf("step %d, score %d\n", i, score)
+		// octopuses.Print()
+		// fmt.Println()
+	}
+	return score
+}
+
+func day11b(octomap *grid.Dense[int]) int {
+	octopuses := NewOctopusGarden(octomap)
+
+	// octopuses.Print()
+
+	for i := 1; ; i++ {
+		octopuses.Step()
+		score := 0
+		n := 1
+		for n != 0 {
+			n = octopuses.MaybeFlash()
+			score += n
+			// fmt.Println(n)
+			if score == 100 {
+				return i
+			}
+		}
+		// if i > 190 {
+		// 	fmt.Printf("step %d, n %d\n", i, n)
+		// 	octopuses.Print()
+		// 	fmt.Println()
+		// }
+	}
+}
+
+func partA(in *input.Input) (interface{}, error) {
+	octomap, err := grid.ParseDigits(in.Text)
+	if err != nil {
+		return nil, err
+	}
+	return day11a(octomap), nil
+}
+
+func partB(in *input.Input) (interface{}, error) {
+	octomap, err := grid.ParseDigits(in.Text)
+	if err != nil {
+		return nil, err
+	}
+	return day11b(octomap), nil
+}
+
+func init() {
+	registry.Register(11, partA, partB)
+}
@@ -0,0 +1,15 @@
+package day11
+
+import (
+	"testing"
+
+	"github.com/kentquirk/aoc2021/bench"
+)
+
+func BenchmarkDay11a(b *testing.B) {
+	bench.Solver(b, partA, "input.txt")
+}
+
+func BenchmarkDay11b(b *testing.B) {
+	bench.Solver(b, partB, "input.txt")
+}
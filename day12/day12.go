@@ -1,12 +1,13 @@
-package main
+package day12
 
 import (
-	"fmt"
-	"io/ioutil"
-	"log"
-	"os"
 	"strings"
 	"unicode"
+
+	"github.com/kentquirk/aoc2021/debug"
+	"github.com/kentquirk/aoc2021/graph"
+	"github.com/kentquirk/aoc2021/input"
+	"github.com/kentquirk/aoc2021/registry"
 )
 
 // returns whether node should be considered a 'big' node
@@ -15,29 +16,32 @@ func isBig(node string) bool {
 	return unicode.IsUpper(rune(node[0]))
 }
 
-// add the edge lhs->rhs to the map (but don't add any edges to 'start'
+// add the edge lhs->rhs to the graph (but don't add any edges to 'start'
 // and don't add any edges from 'end')
-func addToMap(cavemap map[string][]string, lhs string, rhs string) {
+func addToMap(cavemap *graph.Graph[string], lhs string, rhs string) {
 	if lhs != "end" && rhs != "start" {
-		cavemap[lhs] = append(cavemap[lhs], rhs)
+		cavemap.AddEdge(lhs, rhs, 1)
 	}
 }
 
-// parse input lines into an adjacency list representation of the graph.
-// since the graph is non-directional, each edge in the input is added twice:
+// parse input lines into a directed graph.
+// since the caves are non-directional, each edge in the input is added twice:
 // once in each direction.
-func parse(lines []string) map[string][]string {
-	cavemap := make(map[string][]string)
-	for _, l := range lines {
+func parse(lines []string) (*graph.Graph[string], error) {
+	cavemap := graph.New[string]()
+	for i, l := range lines {
 		splits := strings.Split(strings.Trim(l, "\n \t"), "-")
 		// fmt.Println(splits)
+		if len(splits) != 2 || splits[0] == "" || splits[1] == "" {
+			return nil, input.Errorf(i+1, 0, l, "expected two caves joined by '-'")
+		}
 		lhs := splits[0]
 		rhs := splits[1]
 
 		addToMap(cavemap, lhs, rhs)
 		addToMap(cavemap, rhs, lhs)
 	}
-	return cavemap
+	return cavemap, nil
 }
 
 func isAlreadyVisited(visited []string, node string) bool {
@@ -52,10 +56,10 @@ func isAlreadyVisited(visited []string, node string) bool {
 // explore from node, avoiding small rooms we've seen before.
 // (explores recursively, depth first)
 // returns number of found paths from node to 'end'.
-// 'cavemap' is the adjacency-list graph repr
+// 'cavemap' is the graph of caves
 // 'visited' is the list of nodes we've visited in this path so far
-func traverse(cavemap map[string][]string, visited []string, canRevisitSmallCave bool, self string) int {
-	neighbors := cavemap[self]
+func traverse(cavemap *graph.Graph[string], visited []string, canRevisitSmallCave bool, self string) int {
+	neighbors := cavemap.Edges(self)
 	pathCount := 0
 
 	// The original version of this code did this:
@@ -70,7 +74,8 @@ func traverse(cavemap map[string][]string, visited []string, canRevisitSmallCave
 	copy(newVisited, visited)
 	newVisited = append(newVisited, self)
 
-	for _, n := range neighbors {
+	for _, e := range neighbors {
+		n := e.To
 		newCanRevisitSmallCave := canRevisitSmallCave
 		if n == "end" {
 			pathCount++
@@ -99,30 +104,34 @@ func traverse(cavemap map[string][]string, visited []string, canRevisitSmallCave
 	return pathCount
 }
 
-func day12a(lines []string) int {
-	cavemap := parse(lines)
-	fmt.Println(cavemap)
+func day12a(lines []string) (int, error) {
+	cavemap, err := parse(lines)
+	if err != nil {
+		return 0, err
+	}
+	cavemap.WriteDOT(debug.Output, "caves")
 
-	return traverse(cavemap, []string{}, false, "start")
+	return traverse(cavemap, []string{}, false, "start"), nil
 }
 
-func day12b(lines []string) int {
-	cavemap := parse(lines)
-	fmt.Println(cavemap)
+func day12b(lines []string) (int, error) {
+	cavemap, err := parse(lines)
+	if err != nil {
+		return 0, err
+	}
+	cavemap.WriteDOT(debug.Output, "caves")
 
-	return traverse(cavemap, []string{}, true, "start")
+	return traverse(cavemap, []string{}, true, "start"), nil
 }
 
-func main() {
-	f, err := os.Open("./inputlq.txt")
-	if err != nil {
-		log.Fatal(err)
-	}
-	b, err := ioutil.ReadAll(f)
-	if err != nil {
-		log.Fatal(err)
-	}
-	lines := strings.Split(string(b), "\n")
-	fmt.Println(day12a(lines))
-	fmt.Println(day12b(lines))
+func partA(in *input.Input) (interface{}, error) {
+	return day12a(in.Lines())
+}
+
+func partB(in *input.Input) (interface{}, error) {
+	return day12b(in.Lines())
+}
+
+func init() {
+	registry.Register(12, partA, partB)
 }
@@ -0,0 +1,15 @@
+package day12
+
+import (
+	"testing"
+
+	"github.com/kentquirk/aoc2021/bench"
+)
+
+func BenchmarkDay12a(b *testing.B) {
+	bench.Solver(b, partA, "input.txt")
+}
+
+func BenchmarkDay12b(b *testing.B) {
+	bench.Solver(b, partB, "input.txt")
+}
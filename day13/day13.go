@@ -0,0 +1,181 @@
+package day13
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/kentquirk/aoc2021/debug"
+	"github.com/kentquirk/aoc2021/geometry"
+	"github.com/kentquirk/aoc2021/input"
+	"github.com/kentquirk/aoc2021/registry"
+)
+
+type Fold struct {
+	Direction  string
+	Coordinate int
+}
+
+type Paper struct {
+	P map[geometry.Point]struct{}
+	F []Fold
+}
+
+func (p *Paper) Fold(f Fold) {
+	foldedPoints := make(map[geometry.Point]struct{})
+
+	for pt := range p.P {
+		switch f.Direction {
+		case "x":
+			if pt.X > f.Coordinate {
+				pt.X = f.Coordinate - (pt.X - f.Coordinate)
+			}
+		case "y":
+			if pt.Y > f.Coordinate {
+				pt.Y = f.Coordinate - (pt.Y - f.Coordinate)
+			}
+		default:
+			panic("oops")
+		}
+		foldedPoints[pt] = struct{}{}
+	}
+	p.P = foldedPoints
+}
+
+type PointSlice []geometry.Point
+
+func (s PointSlice) Len() int      { return len(s) }
+func (s PointSlice) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s PointSlice) Less(i, j int) bool {
+	if s[i].Y < s[j].Y {
+		return true
+	} else if s[i].Y > s[j].Y {
+		return false
+	} else {
+		return s[i].X < s[j].X
+	}
+}
+
+func (p *Paper) String() string {
+	var points []geometry.Point
+
+	for k := range p.P {
+		points = append(points, k)
+	}
+
+	sort.Sort(PointSlice(points))
+	var sb strings.Builder
+	line := 0
+	col := 0
+	for _, pt := range points {
+		for pt.Y > line {
+			sb.WriteString("\n")
+			col = 0
+			line++
+		}
+		for pt.X >= col {
+			sb.WriteString(" ")
+			col++
+		}
+		sb.WriteString("#")
+		col++
+	}
+	sb.WriteString("\n")
+	return sb.String()
+}
+
+func (p *Paper) Print() {
+	debug.Print(p.String())
+}
+
+func parsePoints(points string) (map[geometry.Point]struct{}, error) {
+	result := make(map[geometry.Point]struct{})
+	for i, p := range strings.Split(points, "\n") {
+		coords := strings.Split(p, ",")
+		if len(coords) != 2 {
+			return nil, input.Errorf(i+1, 0, p, "expected a point like '6,10'")
+		}
+		x, err := strconv.Atoi(coords[0])
+		if err != nil {
+			return nil, input.Errorf(i+1, 1, coords[0], "x must be a number")
+		}
+		y, err := strconv.Atoi(coords[1])
+		if err != nil {
+			return nil, input.Errorf(i+1, len(coords[0])+2, coords[1], "y must be a number")
+		}
+		result[geometry.Point{X: x, Y: y}] = struct{}{}
+	}
+	return result, nil
+}
+
+var foldPat = regexp.MustCompile("^fold along ([xy])=([0-9]+)$")
+
+func parseFolds(folds string) ([]Fold, error) {
+	var result []Fold
+	for i, f := range strings.Split(folds, "\n") {
+		ixs := foldPat.FindStringSubmatchIndex(f)
+		if ixs == nil {
+			return nil, input.Errorf(i+1, 0, f, "expected a fold like 'fold along y=7'")
+		}
+		coord, err := strconv.Atoi(f[ixs[4]:ixs[5]])
+		if err != nil {
+			return nil, input.Errorf(i+1, ixs[4]+1, f[ixs[4]:ixs[5]], "coordinate out of range")
+		}
+		result = append(result, Fold{
+			Direction:  f[ixs[2]:ixs[3]],
+			Coordinate: coord,
+		})
+	}
+	return result, nil
+}
+
+func day13a(paper Paper) int {
+	// fmt.Println(paper)
+	paper.Fold(paper.F[0])
+	// fmt.Println(paper)
+	return len(paper.P)
+}
+
+func day13b(paper Paper) string {
+	for _, f := range paper.F {
+		paper.Fold(f)
+	}
+	return paper.String()
+}
+
+func parseInput(in *input.Input) (Paper, error) {
+	parts := in.Blocks()
+	if len(parts) != 2 {
+		return Paper{}, input.Errorf(1, 0, "", "expected points, a blank line, and folds")
+	}
+	points, err := parsePoints(parts[0])
+	if err != nil {
+		return Paper{}, err
+	}
+	folds, err := parseFolds(parts[1])
+	if err != nil {
+		return Paper{}, input.Offset(err, in.BlockLine(1))
+	}
+	return Paper{P: points, F: folds}, nil
+}
+
+func partA(in *input.Input) (interface{}, error) {
+	paper, err := parseInput(in)
+	if err != nil {
+		return nil, err
+	}
+	return day13a(paper), nil
+}
+
+func partB(in *input.Input) (interface{}, error) {
+	paper, err := parseInput(in)
+	if err != nil {
+		return nil, err
+	}
+	return day13b(paper), nil
+}
+
+func init() {
+	registry.Register(13, partA, partB)
+}
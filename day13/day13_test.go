@@ -0,0 +1,15 @@
+package day13
+
+import (
+	"testing"
+
+	"github.com/kentquirk/aoc2021/bench"
+)
+
+func BenchmarkDay13a(b *testing.B) {
+	bench.Solver(b, partA, "input.txt")
+}
+
+func BenchmarkDay13b(b *testing.B) {
+	bench.Solver(b, partB, "input.txt")
+}
@@ -1,12 +1,12 @@
-package main
+package day14
 
 import (
-	"fmt"
-	"io/ioutil"
-	"log"
 	"math"
-	"os"
 	"strings"
+
+	"github.com/kentquirk/aoc2021/debug"
+	"github.com/kentquirk/aoc2021/input"
+	"github.com/kentquirk/aoc2021/registry"
 )
 
 type Pair struct {
@@ -47,9 +47,9 @@ func NewPolymer(s string) *Polymer {
 
 func (p *Polymer) Print() {
 	for k, v := range p.Pairs {
-		fmt.Printf("%c%c: %d ", k.Left, k.Right, v)
+		debug.Printf("%c%c: %d ", k.Left, k.Right, v)
 	}
-	fmt.Println()
+	debug.Println()
 }
 
 func (p *Polymer) ApplyInsertions(insertions map[Pair]Insertion) {
@@ -85,17 +85,27 @@ func (p *Polymer) Score() int {
 	return (max - min) / 2
 }
 
-func parseInsertions(lines []string) map[Pair]Insertion {
+func parseInsertions(lines []string) (map[Pair]Insertion, error) {
 	insertions := make(map[Pair]Insertion)
 
-	for _, l := range lines {
+	for ix, l := range lines {
 		parts := strings.Split(l, "->")
+		if len(parts) != 2 {
+			return nil, input.Errorf(ix+1, 0, l, "expected a rule like 'CH -> B'")
+		}
 		s := strings.Trim(parts[0], " ")
+		ins := strings.Trim(parts[1], " ")
+		if len(s) != 2 {
+			return nil, input.Errorf(ix+1, strings.Index(l, s)+1, s, "a rule must match a pair of elements")
+		}
+		if len(ins) != 1 {
+			return nil, input.Errorf(ix+1, len(parts[0])+3+strings.Index(parts[1], ins), ins, "a rule must insert a single element")
+		}
 		pair := NewPair(s[0], s[1])
-		i := NewInsertion(pair, strings.Trim(parts[1], " ")[0])
+		i := NewInsertion(pair, ins[0])
 		insertions[pair] = i
 	}
-	return insertions
+	return insertions, nil
 }
 
 func day14a(iterations int, polymer *Polymer, insertions map[Pair]Insertion) int {
@@ -106,19 +116,37 @@ func day14a(iterations int, polymer *Polymer, insertions map[Pair]Insertion) int
 	return polymer.Score()
 }
 
-func main() {
-	f, err := os.Open("./input.txt")
+func parseInput(in *input.Input) (*Polymer, map[Pair]Insertion, error) {
+	lines := in.Lines()
+	if len(lines) < 3 || lines[1] != "" {
+		return nil, nil, input.Errorf(1, 0, "", "expected a template, a blank line, and insertion rules")
+	}
+	if len(lines[0]) < 2 {
+		return nil, nil, input.Errorf(1, 1, lines[0], "the template needs at least two elements")
+	}
+	insertions, err := parseInsertions(lines[2:])
+	if err != nil {
+		return nil, nil, input.Offset(err, 3)
+	}
+	return NewPolymer(lines[0]), insertions, nil
+}
+
+func partA(in *input.Input) (interface{}, error) {
+	polymer, insertions, err := parseInput(in)
 	if err != nil {
-		log.Fatal(err)
+		return nil, err
 	}
-	b, err := ioutil.ReadAll(f)
+	return day14a(10, polymer, insertions), nil
+}
+
+func partB(in *input.Input) (interface{}, error) {
+	polymer, insertions, err := parseInput(in)
 	if err != nil {
-		log.Fatal(err)
+		return nil, err
 	}
-	lines := strings.Split(string(b), "\n")
-	polymer := NewPolymer(lines[0])
-	insertions := parseInsertions(lines[2:])
-	fmt.Println(day14a(10, polymer, insertions))
-	polymer2 := NewPolymer(lines[0])
-	fmt.Println(day14a(40, polymer2, insertions))
+	return day14a(40, polymer, insertions), nil
+}
+
+func init() {
+	registry.Register(14, partA, partB)
 }
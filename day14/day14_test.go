@@ -0,0 +1,15 @@
+package day14
+
+import (
+	"testing"
+
+	"github.com/kentquirk/aoc2021/bench"
+)
+
+func BenchmarkDay14a(b *testing.B) {
+	bench.Solver(b, partA, "input.txt")
+}
+
+func BenchmarkDay14b(b *testing.B) {
+	bench.Solver(b, partB, "input.txt")
+}
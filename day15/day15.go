@@ -0,0 +1,121 @@
+package day15
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/kentquirk/aoc2021/debug"
+	"github.com/kentquirk/aoc2021/graph"
+	"github.com/kentquirk/aoc2021/grid"
+	"github.com/kentquirk/aoc2021/input"
+	"github.com/kentquirk/aoc2021/registry"
+)
+
+type Position struct {
+	Loc  grid.Point
+	Risk int
+}
+
+func NewPosition(pt grid.Point, risk int) *Position {
+	return &Position{
+		Loc:  pt,
+		Risk: risk,
+	}
+}
+
+type Cave struct {
+	Positions *grid.Dense[*Position]
+	Score     int
+}
+
+func (c *Cave) Width() int {
+	return c.Positions.Width()
+}
+
+func (c *Cave) Height() int {
+	return c.Positions.Height()
+}
+
+// Edges makes the cave a graph, where moving into a position costs its risk
+func (c *Cave) Edges(from grid.Point) []graph.Edge[grid.Point] {
+	neighbors := grid.Neighbors[*Position](c.Positions, from, grid.Orthogonal)
+	edges := make([]graph.Edge[grid.Point], 0, len(neighbors))
+	for _, n := range neighbors {
+		edges = append(edges, graph.Edge[grid.Point]{To: n, Weight: c.Positions.Get(n).Risk})
+	}
+	return edges
+}
+
+// newCave fills a cave of the given size with the risk at each point
+func newCave(width int, height int, risk func(pt grid.Point) int) *Cave {
+	cave := &Cave{Positions: grid.NewDense[*Position](width, height)}
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			pt := grid.Point{X: x, Y: y}
+			cave.Positions.Set(pt, NewPosition(pt, risk(pt)))
+		}
+	}
+	return cave
+}
+
+func NewCave(risks *grid.Dense[int]) *Cave {
+	return newCave(risks.Width(), risks.Height(), risks.Get)
+}
+
+// NewCave5 tiles the map 5 times in each direction, adding 1 to the risk
+// for each tile down or across, and wrapping from 9 back to 1
+func NewCave5(risks *grid.Dense[int]) *Cave {
+	w, h := risks.Width(), risks.Height()
+	return newCave(w*5, h*5, func(pt grid.Point) int {
+		r := risks.Get(grid.Point{X: pt.X % w, Y: pt.Y % h})
+		return ((r - 1 + pt.X/w + pt.Y/h) % 9) + 1
+	})
+}
+
+func (c *Cave) PrintWithPath(rawpath []grid.Point) {
+	path := make(map[grid.Point]struct{})
+	for _, r := range rawpath {
+		path[r] = struct{}{}
+	}
+	debug.Print(grid.Format[*Position](c.Positions, func(pos *Position) string {
+		if _, found := path[pos.Loc]; found {
+			return fmt.Sprintf("\x1b[0;34m%d\x1b[0m", pos.Risk)
+		}
+		return strconv.Itoa(pos.Risk)
+	}))
+}
+
+func day15a(cave *Cave) int {
+	start := grid.Point{}
+	exit := grid.Point{X: cave.Width() - 1, Y: cave.Height() - 1}
+
+	path, distance, found := graph.AStar[grid.Point](cave, start, exit, graph.Manhattan)
+	if !found {
+		return -1
+	}
+	for _, p := range path {
+		debug.Println(p, cave.Positions.Get(p).Risk)
+	}
+	// cave.PrintWithPath(path)
+	return distance
+}
+
+func partA(in *input.Input) (interface{}, error) {
+	risks, err := grid.ParseDigits(in.Text)
+	if err != nil {
+		return nil, err
+	}
+	return day15a(NewCave(risks)), nil
+}
+
+func partB(in *input.Input) (interface{}, error) {
+	risks, err := grid.ParseDigits(in.Text)
+	if err != nil {
+		return nil, err
+	}
+	return day15a(NewCave5(risks)), nil
+}
+
+func init() {
+	registry.Register(15, partA, partB)
+}
@@ -0,0 +1,15 @@
+package day15
+
+import (
+	"testing"
+
+	"github.com/kentquirk/aoc2021/bench"
+)
+
+func BenchmarkDay15a(b *testing.B) {
+	bench.Solver(b, partA, "input.txt")
+}
+
+func BenchmarkDay15b(b *testing.B) {
+	bench.Solver(b, partB, "input.txt")
+}
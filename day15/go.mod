@@ -1,5 +0,0 @@
-module github.com/kentquirk/aoc2021/day15
-
-go 1.17
-
-require github.com/beefsack/go-astar v0.0.0-20200827232313-4ecf9e304482 // indirect
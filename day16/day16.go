@@ -1,13 +1,14 @@
-package main
+package day16
 
 import (
-	"fmt"
-	"io/ioutil"
-	"log"
 	"math"
 	"math/big"
-	"os"
 	"strings"
+	"unicode"
+
+	"github.com/kentquirk/aoc2021/debug"
+	"github.com/kentquirk/aoc2021/input"
+	"github.com/kentquirk/aoc2021/registry"
 )
 
 type TypeID uint
@@ -51,16 +52,19 @@ type Stream struct {
 	Cursor uint // 0 is at the left
 }
 
-func NewStream(s string) *Stream {
-	stream := &Stream{}
-	_, ok := stream.Data.SetString(s, 16)
-	if !ok {
-		panic("oops")
+func NewStream(s string) (*Stream, error) {
+	if s == "" {
+		return nil, input.Errorf(1, 0, "", "the transmission is empty")
+	}
+	if ix := strings.IndexFunc(s, func(r rune) bool { return !unicode.Is(unicode.ASCII_Hex_Digit, r) }); ix != -1 {
+		return nil, input.Errorf(1, ix+1, s[ix:ix+1], "not a hexadecimal digit")
 	}
+	stream := &Stream{}
+	stream.Data.SetString(s, 16)
 	stream.Len = uint(len(s) * 4)
 
 	// fmt.Println(stream.Data.Text(16))
-	return stream
+	return stream, nil
 }
 
 func (s *Stream) Range(pos uint, n uint) uint {
@@ -183,33 +187,50 @@ func (p *Packet) Evaluate() uint {
 }
 
 func (p *Packet) Print(n int) {
-	fmt.Printf("%sv%d %s (%d)\n", strings.Repeat("  ", n), p.Version, p.Type, p.Evaluate())
+	debug.Printf("%sv%d %s (%d)\n", strings.Repeat("  ", n), p.Version, p.Type, p.Evaluate())
 	for _, sub := range p.Subpackets {
 		sub.Print(n + 1)
 	}
 }
 
-func day16a(line string) uint {
-	s := NewStream(line)
-	fmt.Println(" ----\n", line[:4])
+func day16a(line string) (uint, error) {
+	s, err := NewStream(line)
+	if err != nil {
+		return 0, err
+	}
+	debug.Println(" ----\n", line[:4])
 	p, _ := s.ReadPacket()
 	p.Print(0)
 	r := p.SumVersions()
-	fmt.Printf("-> %d\n", r)
-	return r
+	debug.Printf("-> %d\n", r)
+	return r, nil
 }
 
-func main() {
-	f, err := os.Open("./input.txt")
-	if err != nil {
-		log.Fatal(err)
-	}
-	b, err := ioutil.ReadAll(f)
+func day16b(line string) (uint, error) {
+	s, err := NewStream(line)
 	if err != nil {
-		log.Fatal(err)
+		return 0, err
 	}
-	lines := strings.Split(string(b), "\n")
-	for _, line := range lines {
-		day16a(line)
+	p, _ := s.ReadPacket()
+	return p.Evaluate(), nil
+}
+
+// the puzzle input is a single transmission on the first line
+func partA(in *input.Input) (interface{}, error) {
+	return day16a(firstLine(in))
+}
+
+func partB(in *input.Input) (interface{}, error) {
+	return day16b(firstLine(in))
+}
+
+func firstLine(in *input.Input) string {
+	if lines := in.Lines(); len(lines) > 0 {
+		return lines[0]
 	}
+	return ""
+}
+
+func init() {
+	registry.Register(16, partA, partB)
 }
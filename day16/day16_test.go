@@ -0,0 +1,15 @@
+package day16
+
+import (
+	"testing"
+
+	"github.com/kentquirk/aoc2021/bench"
+)
+
+func BenchmarkDay16a(b *testing.B) {
+	bench.Solver(b, partA, "input.txt")
+}
+
+func BenchmarkDay16b(b *testing.B) {
+	bench.Solver(b, partB, "input.txt")
+}
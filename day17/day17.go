@@ -1,54 +1,54 @@
-package main
+package day17
 
 import (
-	"fmt"
-	"io/ioutil"
-	"log"
-	"os"
 	"regexp"
 	"strconv"
-	"strings"
-)
 
-type Point struct {
-	X int
-	Y int
-}
+	"github.com/kentquirk/aoc2021/geometry"
+	"github.com/kentquirk/aoc2021/input"
+	"github.com/kentquirk/aoc2021/registry"
+)
 
 type Probe struct {
-	Loc  Point
-	Vel  Point
+	Loc  geometry.Point
+	Vel  geometry.Point
 	YMax int
 }
 
 type Area struct {
-	Xmin int
-	Ymin int
-	Xmax int
-	Ymax int
+	geometry.Box
 }
 
-func ParseArea(s string) *Area {
+func ParseArea(s string) (*Area, error) {
 	re := regexp.MustCompile("x=([0-9-]+)..([0-9-]+), y=([0-9-]+)..([0-9-]+)")
-	ms := re.FindStringSubmatch(s)
-	a := Area{}
-	a.Xmin, _ = strconv.Atoi(ms[1])
-	a.Xmax, _ = strconv.Atoi(ms[2])
-	a.Ymin, _ = strconv.Atoi(ms[3])
-	a.Ymax, _ = strconv.Atoi(ms[4])
-	return &a
+	ixs := re.FindStringSubmatchIndex(s)
+	if ixs == nil {
+		return nil, input.Errorf(1, 0, s, "expected a target area like 'x=20..30, y=-10..-5'")
+	}
+	var xy [4]int
+	for i := range xy {
+		lo, hi := ixs[2*i+2], ixs[2*i+3]
+		n, err := strconv.Atoi(s[lo:hi])
+		if err != nil {
+			line, col := input.Position(s, lo)
+			return nil, input.Errorf(line, col, s[lo:hi], "not a number")
+		}
+		xy[i] = n
+	}
+	box := geometry.NewBox(geometry.Point{X: xy[0], Y: xy[2]}, geometry.Point{X: xy[1], Y: xy[3]})
+	return &Area{box}, nil
 }
 
-func (a *Area) IsBelow(pt Point) bool {
-	return pt.Y < a.Ymin
+func (a *Area) IsBelow(pt geometry.Point) bool {
+	return pt.Y < a.Min.Y
 }
 
-func (a *Area) IsBeyond(pt Point) bool {
-	return pt.X > a.Xmax
+func (a *Area) IsBeyond(pt geometry.Point) bool {
+	return pt.X > a.Max.X
 }
 
-func (a *Area) IsInside(pt Point) bool {
-	return !(pt.X < a.Xmin || pt.X > a.Xmax || pt.Y < a.Ymin || pt.Y > a.Ymax)
+func (a *Area) IsInside(pt geometry.Point) bool {
+	return a.Contains(pt)
 }
 
 // Fire sends one probe and returns the results --
@@ -56,14 +56,13 @@ func (a *Area) IsInside(pt Point) bool {
 // If it's a hit, then both beyond and below will be false
 func (a *Area) Fire(dx, dy int) (int, bool, bool) {
 	probe := Probe{
-		Loc:  Point{},
-		Vel:  Point{X: dx, Y: dy},
+		Loc:  geometry.Point{},
+		Vel:  geometry.Point{X: dx, Y: dy},
 		YMax: 0,
 	}
 	for {
 		// adjust probe
-		probe.Loc.X += probe.Vel.X
-		probe.Loc.Y += probe.Vel.Y
+		probe.Loc = probe.Loc.Add(probe.Vel)
 		switch {
 		case probe.Vel.X > 0:
 			probe.Vel.X--
@@ -102,11 +101,11 @@ func (a *Area) Fire(dx, dy int) (int, bool, bool) {
 // We record all the hit velocities, and afterward, we iterate around those positions
 // to try to find more.
 func day17a(area *Area) (int, int) {
-	hits := make(map[Point]int)
+	hits := make(map[geometry.Point]int)
 	// seed it with the fastest direct shot that will work
-	hits[Point{area.Xmax, area.Ymin}] = 0
+	hits[geometry.Point{X: area.Max.X, Y: area.Min.Y}] = 0
 	dx := 1
-	dy := area.Ymin
+	dy := area.Min.Y
 	maxheight := 0
 	first := true
 	for dx != 0 && dy < 1000 {
@@ -117,7 +116,7 @@ func day17a(area *Area) (int, int) {
 			if height > maxheight {
 				maxheight = height
 			}
-			hits[Point{X: dx, Y: dy}] = maxheight
+			hits[geometry.Point{X: dx, Y: dy}] = maxheight
 			// fmt.Printf("hit %d with (%d, %d)", maxheight, dx, dy)
 			dy++
 			dx--
@@ -138,11 +137,11 @@ func day17a(area *Area) (int, int) {
 
 	slop := 3
 	for {
-		newhits := make(map[Point]int)
+		newhits := make(map[geometry.Point]int)
 		for vel := range hits {
 			for x := -slop; x <= slop; x++ {
 				for y := -slop; y <= slop; y++ {
-					trial := Point{vel.X + x, vel.Y + y}
+					trial := vel.Add(geometry.Point{X: x, Y: y})
 					if _, found := hits[trial]; !found {
 						if height, beyond, below := area.Fire(trial.X, trial.Y); !beyond && !below {
 							newhits[trial] = height
@@ -164,16 +163,24 @@ func day17a(area *Area) (int, int) {
 	return maxheight, len(hits)
 }
 
-func main() {
-	f, err := os.Open("./input.txt")
+func partA(in *input.Input) (interface{}, error) {
+	area, err := ParseArea(in.Text)
 	if err != nil {
-		log.Fatal(err)
+		return nil, err
 	}
-	b, err := ioutil.ReadAll(f)
+	maxheight, _ := day17a(area)
+	return maxheight, nil
+}
+
+func partB(in *input.Input) (interface{}, error) {
+	area, err := ParseArea(in.Text)
 	if err != nil {
-		log.Fatal(err)
+		return nil, err
 	}
-	lines := strings.Split(string(b), "\n")
-	area := ParseArea(lines[0])
-	fmt.Println(day17a(area))
+	_, nhits := day17a(area)
+	return nhits, nil
+}
+
+func init() {
+	registry.Register(17, partA, partB)
 }
@@ -0,0 +1,15 @@
+package day17
+
+import (
+	"testing"
+
+	"github.com/kentquirk/aoc2021/bench"
+)
+
+func BenchmarkDay17a(b *testing.B) {
+	bench.Solver(b, partA, "input.txt")
+}
+
+func BenchmarkDay17b(b *testing.B) {
+	bench.Solver(b, partB, "input.txt")
+}
@@ -1,13 +1,13 @@
-package main
+package day18
 
 import (
 	"fmt"
-	"io/ioutil"
-	"log"
-	"os"
 	"regexp"
 	"strconv"
 	"strings"
+
+	"github.com/kentquirk/aoc2021/input"
+	"github.com/kentquirk/aoc2021/registry"
 )
 
 type Value struct {
@@ -165,7 +165,7 @@ func (p *Pair) explode() (*Pair, bool) {
 		rtv, _ := strconv.Atoi(s[ixs[4]:ixs[5]])
 		leftPart = addToPrev(leftPart, ltv)
 		rightPart := addToNext(s[ixs[1]:], rtv)
-		p2 := Parse(leftPart + "0" + rightPart)
+		p2 := MustParse(leftPart + "0" + rightPart)
 		return p2, true
 	}
 	return p, false
@@ -180,37 +180,56 @@ func (p *Pair) split() (*Pair, bool) {
 		right := nums[0][1]
 		v, _ := strconv.Atoi(s[left:right])
 		newS := fmt.Sprintf("%s[%d,%d]%s", s[:left], v/2, int(float64(v)/2+.6), s[right:])
-		return Parse(newS), true
+		return MustParse(newS), true
 	}
 	return p, false
 }
 
-// Parses a string to read a complete pair from it, expecting
-// that the first character is '['; terminates after reading
-// the corresponding closing ']', returning the number of characters
-// processed
-func Parse(s string) *Pair {
-	p, _ := parse(s)
+// Parse reads a complete snailfish number from a string, which must
+// start with '[' and end with the corresponding closing ']'.
+func Parse(s string) (*Pair, error) {
+	p, n, err := parse(s, 0)
+	if err != nil {
+		return nil, err
+	}
+	if n != len(s)-1 {
+		return nil, input.Errorf(1, n+2, s[n+1:], "unexpected text after the closing ']'")
+	}
+	return p, nil
+}
+
+// MustParse is like Parse but panics if s isn't a valid snailfish number.
+// It's for strings we built ourselves (or in tests) that can't be wrong.
+func MustParse(s string) *Pair {
+	p, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
 	return p
 }
 
-func parse(s string) (*Pair, int) {
+// parse reads a pair from the start of s, where s begins at column start+1
+// of the line; returns the pair and the index of its closing ']' in s.
+func parse(s string, start int) (*Pair, int, error) {
 	pair := &Pair{}
-	if s[0] != '[' {
-		panic("oops")
+	if len(s) == 0 || s[0] != '[' {
+		return nil, 0, input.Errorf(1, start+1, s, "a pair must start with '['")
 	}
 	isLeft := true
 	for ix := 1; ix < len(s); ix++ {
 		ch := s[ix]
 		switch {
 		case ch == '[':
-			p, n := parse(s[ix:])
+			p, n, err := parse(s[ix:], start+ix)
+			if err != nil {
+				return nil, 0, err
+			}
 			pair.Set(&Value{Pair: p}, isLeft)
 			ix += n
 		case strings.IndexByte("0123456789", ch) != -1:
 			n := int(ch - '0')
 			// because explode might create 2-digit numbers, we need to handle this case
-			if strings.IndexByte("0123456789", s[ix+1]) != -1 {
+			if ix+1 < len(s) && strings.IndexByte("0123456789", s[ix+1]) != -1 {
 				n = n*10 + int(s[ix+1]-'0')
 				ix++
 			}
@@ -218,35 +237,52 @@ func parse(s string) (*Pair, int) {
 		case ch == ',':
 			isLeft = false
 		case ch == ']':
-			return pair, ix
+			if pair.Left == nil || pair.Right == nil {
+				return nil, 0, input.Errorf(1, start+ix+1, "]", "a pair needs two elements")
+			}
+			return pair, ix, nil
 		default:
-			panic("oopsie")
+			return nil, 0, input.Errorf(1, start+ix+1, string(ch), "unexpected character")
 		}
 	}
-	panic("whoops")
+	return nil, 0, input.Errorf(1, start+len(s), s, "missing ']'")
 }
 
-func SumList(lines []string) *Pair {
-	lhs := Parse(lines[0])
-	for _, l := range lines[1:] {
-		// fmt.Println(l)
-		rhs := Parse(l)
+func parseLines(lines []string) ([]*Pair, error) {
+	var pairs []*Pair
+	for i, l := range lines {
+		p, err := Parse(l)
+		if err != nil {
+			return nil, input.Offset(err, i+1)
+		}
+		pairs = append(pairs, p)
+	}
+	return pairs, nil
+}
+
+func Sum(pairs []*Pair) *Pair {
+	lhs := pairs[0]
+	for _, rhs := range pairs[1:] {
 		lhs = lhs.Add(rhs)
 		// fmt.Printf("sum: %s\n", lhs)
 	}
 	return lhs
 }
 
-func day18a(lines []string) int {
-	p := SumList(lines)
-	return p.Magnitude()
-}
-
-func day18b(lines []string) int {
+func SumList(lines []string) *Pair {
 	var pairs []*Pair
 	for _, l := range lines {
-		pairs = append(pairs, Parse(l))
+		pairs = append(pairs, MustParse(l))
 	}
+	return Sum(pairs)
+}
+
+func day18a(pairs []*Pair) int {
+	p := Sum(pairs)
+	return p.Magnitude()
+}
+
+func day18b(pairs []*Pair) int {
 	largest := 0
 	for i := 0; i < len(pairs)-1; i++ {
 		for j := i; j < len(pairs)-1; j++ {
@@ -265,16 +301,22 @@ func day18b(lines []string) int {
 	return largest
 }
 
-func main() {
-	f, err := os.Open("./input.txt")
+func partA(in *input.Input) (interface{}, error) {
+	pairs, err := parseLines(in.Lines())
 	if err != nil {
-		log.Fatal(err)
+		return nil, err
 	}
-	b, err := ioutil.ReadAll(f)
+	return day18a(pairs), nil
+}
+
+func partB(in *input.Input) (interface{}, error) {
+	pairs, err := parseLines(in.Lines())
 	if err != nil {
-		log.Fatal(err)
+		return nil, err
 	}
-	lines := strings.Split(string(b), "\n")
-	fmt.Println(day18a(lines))
-	fmt.Println(day18b(lines))
+	return day18b(pairs), nil
+}
+
+func init() {
+	registry.Register(18, partA, partB)
 }
@@ -1,8 +1,10 @@
-package main
+package day18
 
 import (
 	"regexp"
 	"testing"
+
+	"github.com/kentquirk/aoc2021/bench"
 )
 
 func TestExplode(t *testing.T) {
@@ -23,7 +25,7 @@ func TestExplode(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			p := Parse(tt.input)
+			p := MustParse(tt.input)
 			got, exploded := p.explode()
 			s := got.String()
 			if exploded != tt.wantBoom {
@@ -72,7 +74,7 @@ func TestPair_split(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			p := Parse(tt.p)
+			p := MustParse(tt.p)
 			got, got1 := p.split()
 			if got.String() != tt.want {
 				t.Errorf("Pair.split() got = %v, want %v", got, tt.want)
@@ -95,7 +97,7 @@ func TestPair_Reduce(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			p := Parse(tt.p)
+			p := MustParse(tt.p)
 			got := p.Reduce()
 			if got.String() != tt.want {
 				t.Errorf("Pair.Reduce() \n got = '%v', \nwant = '%v'", got, tt.want)
@@ -118,8 +120,8 @@ func TestAdd(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			lhs := Parse(tt.lhs)
-			rhs := Parse(tt.rhs)
+			lhs := MustParse(tt.lhs)
+			rhs := MustParse(tt.rhs)
 			got := lhs.Add(rhs)
 
 			if got.String() != tt.want {
@@ -177,3 +179,11 @@ func TestSumList(t *testing.T) {
 		})
 	}
 }
+
+func BenchmarkDay18a(b *testing.B) {
+	bench.Solver(b, partA, "input.txt")
+}
+
+func BenchmarkDay18b(b *testing.B) {
+	bench.Solver(b, partB, "input.txt")
+}
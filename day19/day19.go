@@ -1,76 +1,17 @@
-package main
+package day19
 
 import (
-	"fmt"
-	"io/ioutil"
-	"log"
-	"os"
 	"sort"
 	"strconv"
 	"strings"
-)
-
-type Point [3]int
-
-func (p Point) Add(rhs Point) Point {
-	return Point{rhs[0] + p[0], rhs[1] + p[1], rhs[2] + p[2]}
-}
-
-func (p Point) VectorTo(rhs Point) Point {
-	return Point{rhs[0] - p[0], rhs[1] - p[1], rhs[2] - p[2]}
-}
-
-func (p Point) Less(rhs Point) bool {
-	return p[0] < rhs[0] ||
-		p[0] == rhs[0] && p[1] < rhs[1] ||
-		p[0] == rhs[0] && p[1] == rhs[1] && p[2] < rhs[2]
-}
 
-func iabs(x int) int {
-	if x < 0 {
-		return -x
-	}
-	return x
-}
-
-func (p Point) Manhattan(rhs Point) int {
-	return iabs(rhs[0]-p[0]) + iabs(rhs[1]-p[1]) + iabs(rhs[2]-p[2])
-}
-
-func (p Point) Dist2(rhs Point) int {
-	dx := rhs[0] - p[0]
-	dy := rhs[1] - p[1]
-	dz := rhs[2] - p[2]
-	return dx*dx + dy*dy + dz*dz
-}
-
-var Selections = [][3]int{
-	{0, 1, 2},
-	{0, 2, 1},
-	{1, 2, 0},
-	{1, 0, 2},
-	{2, 0, 1},
-	{2, 1, 0},
-}
-
-func flip(x int, b bool) int {
-	if b {
-		return -x
-	}
-	return x
-}
-
-func (p Point) Reorient(selection int, signs int) Point {
-	return Point{
-		flip(p[Selections[selection][0]], signs&1 != 0),
-		flip(p[Selections[selection][1]], signs&2 != 0),
-		flip(p[Selections[selection][2]], signs&4 != 0),
-	}
-}
+	"github.com/kentquirk/aoc2021/debug"
+	"github.com/kentquirk/aoc2021/geometry"
+	"github.com/kentquirk/aoc2021/input"
+	"github.com/kentquirk/aoc2021/registry"
+)
 
-func (p Point) String() string {
-	return fmt.Sprintf("(%d,%d,%d)", p[0], p[1], p[2])
-}
+type Point = geometry.Point3
 
 type PointSlice []Point
 
@@ -88,34 +29,42 @@ func (p PointSlice) Contains(pt Point) bool {
 }
 
 type Scanner struct {
-	Points    PointSlice
-	Selection int // 0 to 5
-	Signs     int // 0 to 7
-	Location  Point
+	Points   PointSlice
+	Rotation geometry.Rotation
+	Location Point
 }
 
 func NewScanner() *Scanner {
 	return &Scanner{
-		Points:    make(PointSlice, 0),
-		Selection: 0,
-		Signs:     0,
-		Location:  Point{0, 0, 0},
+		Points:   make(PointSlice, 0),
+		Rotation: geometry.Identity,
 	}
 }
 
-func (s *Scanner) Reorient(sel, signs int) {
+func (s *Scanner) Reorient(r geometry.Rotation) {
 	for i := range s.Points {
-		s.Points[i] = s.Points[i].Reorient(sel, signs)
+		s.Points[i] = r.Apply(s.Points[i])
 	}
+	s.Rotation = r.Compose(s.Rotation)
 }
 
-func MakePoint(s string) Point {
+func MakePoint(s string) (Point, error) {
 	var pt Point
+	coords := []*int{&pt.X, &pt.Y, &pt.Z}
 	vs := strings.Split(s, ",")
+	if len(vs) != 3 {
+		return pt, input.Errorf(1, 0, s, "expected a point like '404,-588,-901'")
+	}
+	col := 1
 	for i := range vs {
-		pt[i], _ = strconv.Atoi(vs[i])
+		n, err := strconv.Atoi(vs[i])
+		if err != nil {
+			return pt, input.Errorf(1, col, vs[i], "not a number")
+		}
+		*coords[i] = n
+		col += len(vs[i]) + 1
 	}
-	return pt
+	return pt, nil
 }
 
 // We have two point clouds (a & b) for a pair of scanners.
@@ -153,28 +102,26 @@ func comparePointSlices(p1, p2 PointSlice) (Point, bool) {
 // iterate through every possible orientation to try to find a match
 // if we find one, reorient and position the target scanner and return the offset and true
 func (s *Scanner) SearchForMatch(base *Scanner) (Point, bool) {
-	for sel := 0; sel < 6; sel++ {
-		for sign := 0; sign < 8; sign++ {
-			pts := make(PointSlice, len(s.Points))
-			for i := range s.Points {
-				pts[i] = s.Points[i].Reorient(sel, sign)
-			}
-			if offset, found := comparePointSlices(base.Points, pts); found {
-				s.Reorient(sel, sign)
-				return offset, true
-			}
+	for _, r := range geometry.Rotations {
+		pts := make(PointSlice, len(s.Points))
+		for i := range s.Points {
+			pts[i] = r.Apply(s.Points[i])
+		}
+		if offset, found := comparePointSlices(base.Points, pts); found {
+			s.Reorient(r)
+			return offset, true
 		}
 	}
 
 	return Point{}, false
 }
 
-func day19a(lines []string) (int, int) {
+func day19a(lines []string) (int, int, error) {
 	unmatched := make(map[int]*Scanner)
 	matched := make(map[int]*Scanner)
 	var scanner *Scanner
 	var key = -1
-	for _, l := range lines {
+	for i, l := range lines {
 		if len(l) == 0 {
 			continue
 		}
@@ -184,7 +131,17 @@ func day19a(lines []string) (int, int) {
 			unmatched[key] = scanner
 			continue
 		}
-		scanner.Points = append(scanner.Points, MakePoint(l))
+		if scanner == nil {
+			return 0, 0, input.Errorf(i+1, 0, l, "expected a scanner header like '--- scanner 0 ---'")
+		}
+		pt, err := MakePoint(l)
+		if err != nil {
+			return 0, 0, input.Offset(err, i+1)
+		}
+		scanner.Points = append(scanner.Points, pt)
+	}
+	if len(unmatched) == 0 {
+		return 0, 0, input.Errorf(1, 0, "", "there are no scanners")
 	}
 	matched[0] = unmatched[0]
 	delete(unmatched, 0)
@@ -196,7 +153,7 @@ func day19a(lines []string) (int, int) {
 		for baseKey, baseScanner := range matched {
 			for unmatchedKey, unmatchedScanner := range unmatched {
 				if offset, found := unmatchedScanner.SearchForMatch(baseScanner); found {
-					fmt.Printf("Found match: base(%d) unmatched(%d)\n", baseKey, unmatchedKey)
+					debug.Printf("Found match: base(%d) unmatched(%d)\n", baseKey, unmatchedKey)
 					unmatchedScanner.Location = offset.Add(baseScanner.Location)
 					matched[unmatchedKey] = unmatched[unmatchedKey]
 					delete(unmatched, unmatchedKey)
@@ -207,7 +164,7 @@ func day19a(lines []string) (int, int) {
 	}
 	beacons := make(map[Point]struct{})
 	for k, s := range matched {
-		fmt.Printf("%d: %v\n", k, s.Location)
+		debug.Printf("%d: %v\n", k, s.Location)
 		for _, pt := range s.Points {
 			beacons[pt.Add(s.Location)] = struct{}{}
 		}
@@ -222,18 +179,25 @@ func day19a(lines []string) (int, int) {
 			}
 		}
 	}
-	return len(beacons), maxDist
+	return len(beacons), maxDist, nil
 }
 
-func main() {
-	f, err := os.Open("./input.txt")
+func partA(in *input.Input) (interface{}, error) {
+	nbeacons, _, err := day19a(in.Lines())
 	if err != nil {
-		log.Fatal(err)
+		return nil, err
 	}
-	b, err := ioutil.ReadAll(f)
+	return nbeacons, nil
+}
+
+func partB(in *input.Input) (interface{}, error) {
+	_, maxDist, err := day19a(in.Lines())
 	if err != nil {
-		log.Fatal(err)
+		return nil, err
 	}
-	lines := strings.Split(string(b), "\n")
-	fmt.Println(day19a(lines))
+	return maxDist, nil
+}
+
+func init() {
+	registry.Register(19, partA, partB)
 }
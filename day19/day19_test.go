@@ -0,0 +1,15 @@
+package day19
+
+import (
+	"testing"
+
+	"github.com/kentquirk/aoc2021/bench"
+)
+
+func BenchmarkDay19a(b *testing.B) {
+	bench.Solver(b, partA, "input.txt")
+}
+
+func BenchmarkDay19b(b *testing.B) {
+	bench.Solver(b, partB, "input.txt")
+}
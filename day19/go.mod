@@ -1,3 +0,0 @@
-module github.com/kentquirk/aoc2021/day19
-
-go 1.17
@@ -0,0 +1,154 @@
+package day20
+
+import (
+	"math/big"
+	"regexp"
+	"strings"
+
+	"github.com/kentquirk/aoc2021/debug"
+	"github.com/kentquirk/aoc2021/grid"
+	"github.com/kentquirk/aoc2021/input"
+	"github.com/kentquirk/aoc2021/registry"
+)
+
+type Algorithm big.Int
+
+// the algorithm may be wrapped onto several lines
+func parseAlgorithm(s string) (*Algorithm, error) {
+	if ix := strings.IndexFunc(s, func(r rune) bool { return !strings.ContainsRune(".#\n\r\t ", r) }); ix != -1 {
+		line, col := input.Position(s, ix)
+		return nil, input.Errorf(line, col, s[ix:ix+1], "the algorithm must be made of '#' and '.'")
+	}
+	r := regexp.MustCompile("[^.#]+")
+	s = r.ReplaceAllString(s, "")
+	s = strings.Replace(s, ".", "0", -1)
+	s = strings.Replace(s, "#", "1", -1)
+	if len(s) != 512 {
+		return nil, input.Errorf(1, 0, "", "the algorithm must be 512 characters, not %d", len(s))
+	}
+	i := new(big.Int)
+	i.SetString(s, 2)
+	return (*Algorithm)(i), nil
+}
+
+func (a *Algorithm) GetBit(index int) bool {
+	return ((*big.Int)(a)).Bit(511-index) == 1
+}
+
+func (a *Algorithm) NeedsInvert() bool {
+	return a.GetBit(0) && !a.GetBit(511)
+}
+
+// we're going to do a sparse image -- store only the pixels that differ from
+// the background -- that way, looking up a cell that isn't stored returns the
+// background, and we can get the number of lit pixels with Len.
+// The background is lit in an inverted image.
+type Image struct {
+	*grid.Sparse[bool]
+}
+
+func MakeImage(inverted bool) Image {
+	return Image{grid.NewSparse(inverted)}
+}
+
+func (img Image) IsInverted() bool {
+	return img.Background
+}
+
+// EnhancePixel reads the 3x3 square around a point as a 9-bit number,
+// starting from the top left
+func (img Image) EnhancePixel(c grid.Point) int {
+	bits := 0
+	for y := c.Y - 1; y <= c.Y+1; y++ {
+		for x := c.X - 1; x <= c.X+1; x++ {
+			bits <<= 1
+			if img.Get(grid.Point{X: x, Y: y}) {
+				bits |= 1
+			}
+		}
+	}
+	return bits
+}
+
+func (img Image) Count() int {
+	return img.Len()
+}
+
+func parseImage(s string) (Image, error) {
+	pixels, err := grid.ParsePixels(s)
+	if err != nil {
+		return Image{}, err
+	}
+	return Image{grid.ToSparse[bool](pixels, false)}, nil
+}
+
+func (img Image) Enhance(algo *Algorithm) Image {
+	shouldInvert := algo.NeedsInvert() && !img.IsInverted()
+	result := MakeImage(shouldInvert)
+	lo, hi := img.Bounds()
+	for y := lo.Y - 1; y <= hi.Y+1; y++ {
+		for x := lo.X - 1; x <= hi.X+1; x++ {
+			pt := grid.Point{X: x, Y: y}
+			bitIndex := img.EnhancePixel(pt)
+			result.Set(pt, algo.GetBit(bitIndex))
+		}
+	}
+	return result
+}
+
+func (img Image) Print() {
+	debug.Println(grid.FormatPixels(img))
+}
+
+// enhance applies the algorithm nTimes and returns the number of lit pixels
+func enhance(algo *Algorithm, img Image, nTimes int) int {
+	// img.Print()
+	for i := 0; i < nTimes; i++ {
+		img = img.Enhance(algo)
+	}
+	return img.Count()
+}
+
+func day20a(algo *Algorithm, img Image) int {
+	return enhance(algo, img, 2)
+}
+
+func day20b(algo *Algorithm, img Image) int {
+	return enhance(algo, img, 50)
+}
+
+func parseInput(in *input.Input) (*Algorithm, Image, error) {
+	parts := in.Blocks()
+	if len(parts) != 2 {
+		return nil, Image{}, input.Errorf(1, 0, "", "expected the algorithm, a blank line, and the image")
+	}
+	algo, err := parseAlgorithm(parts[0])
+	if err != nil {
+		return nil, Image{}, err
+	}
+	img, err := parseImage(parts[1])
+	if err != nil {
+		return nil, Image{}, input.Offset(err, in.BlockLine(1))
+	}
+	return algo, img, nil
+}
+
+func partA(in *input.Input) (interface{}, error) {
+	algo, img, err := parseInput(in)
+	if err != nil {
+		return nil, err
+	}
+	return day20a(algo, img), nil
+}
+
+func partB(in *input.Input) (interface{}, error) {
+	algo, img, err := parseInput(in)
+	if err != nil {
+		return nil, err
+	}
+	return day20b(algo, img), nil
+}
+
+func init() {
+	registry.Register(20, partA, partB)
+}
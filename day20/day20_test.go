@@ -0,0 +1,15 @@
+package day20
+
+import (
+	"testing"
+
+	"github.com/kentquirk/aoc2021/bench"
+)
+
+func BenchmarkDay20a(b *testing.B) {
+	bench.Solver(b, partA, "input.txt")
+}
+
+func BenchmarkDay20b(b *testing.B) {
+	bench.Solver(b, partB, "input.txt")
+}
@@ -0,0 +1,24 @@
+// Package debug is where the solutions send their diagnostic chatter, so
+// that it stays out of the way of the answers on stdout.
+package debug
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+// Output is where debug output goes; set it to io.Discard to silence it.
+var Output io.Writer = os.Stderr
+
+func Print(a ...interface{}) {
+	fmt.Fprint(Output, a...)
+}
+
+func Printf(format string, a ...interface{}) {
+	fmt.Fprintf(Output, format, a...)
+}
+
+func Println(a ...interface{}) {
+	fmt.Fprintln(Output, a...)
+}
@@ -0,0 +1,174 @@
+package fetch
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// ChecksumFile is the name of the file in each day's directory that records
+// the checksums of the files that have been cached there.
+const ChecksumFile = "checksums.json"
+
+// Checksums maps the name of a file in a day's directory to its checksum.
+type Checksums map[string]string
+
+// Checksum returns the checksum recorded for data.
+func Checksum(data []byte) string {
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// LoadChecksums reads the checksums recorded in a day's directory. A
+// directory without a checksum file has no checksums.
+func LoadChecksums(dir string) (Checksums, error) {
+	data, err := os.ReadFile(filepath.Join(dir, ChecksumFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return make(Checksums), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	sums := make(Checksums)
+	if err := json.Unmarshal(data, &sums); err != nil {
+		return nil, fmt.Errorf("%s: %v", filepath.Join(dir, ChecksumFile), err)
+	}
+	return sums, nil
+}
+
+// Save writes the checksums to a day's directory.
+func (sums Checksums) Save(dir string) error {
+	data, err := json.MarshalIndent(sums, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, ChecksumFile), append(data, '\n'), 0644)
+}
+
+// Cache keeps each day's downloaded files in the day's directory under
+// root, which is where the runner looks for them.
+type Cache struct {
+	Root   string
+	Client *Client
+}
+
+// Result describes one file in the cache after a Fetch.
+type Result struct {
+	Day     int
+	Path    string
+	Fetched bool // false if the file was already cached
+}
+
+// the files that make up a day, and how to download each of them
+var files = []struct {
+	name     string
+	download func(c *Client, day int) ([]byte, error)
+}{
+	{"input.txt", (*Client).Input},
+	{"inputsample.txt", (*Client).Sample},
+}
+
+// Dir returns the directory a day's files are kept in.
+func (c *Cache) Dir(day int) string {
+	return filepath.Join(c.Root, fmt.Sprintf("day%02d", day))
+}
+
+// Fetch makes sure a day's input and sample are in the cache, downloading
+// only the ones that aren't. A file that's already there is never fetched
+// again; its checksum is recorded the first time it's seen, and checked
+// every time after that. An empty file (like the ones "aoc new" creates)
+// is a placeholder, not a cached file.
+func (c *Cache) Fetch(day int) ([]Result, error) {
+	dir := c.Dir(day)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	sums, err := LoadChecksums(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []Result
+	changed := false
+	for _, f := range files {
+		var r Result
+		had := sums[f.name]
+		r, err = c.fetchFile(day, sums, f.name, f.download)
+		changed = changed || sums[f.name] != had
+		if err != nil {
+			break
+		}
+		results = append(results, r)
+	}
+	if changed {
+		// even if something failed, remember what we did get
+		if err := sums.Save(dir); err != nil {
+			return results, err
+		}
+	}
+	return results, err
+}
+
+func (c *Cache) fetchFile(day int, sums Checksums, name string, download func(*Client, int) ([]byte, error)) (Result, error) {
+	dir := c.Dir(day)
+	path := filepath.Join(dir, name)
+	data, err := os.ReadFile(path)
+	switch {
+	case err == nil && len(data) > 0:
+		if err := sums.check(dir, name, data); err != nil {
+			return Result{}, err
+		}
+		sums[name] = Checksum(data)
+		return Result{Day: day, Path: path}, nil
+	case err != nil && !errors.Is(err, fs.ErrNotExist):
+		return Result{}, err
+	}
+
+	data, err = download(c.Client, day)
+	if err != nil {
+		return Result{}, err
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return Result{}, err
+	}
+	sums[name] = Checksum(data)
+	return Result{Day: day, Path: path, Fetched: true}, nil
+}
+
+// Verify checks the cached files for a day against their recorded checksums.
+func (c *Cache) Verify(day int) error {
+	dir := c.Dir(day)
+	sums, err := LoadChecksums(dir)
+	if err != nil {
+		return err
+	}
+	var names []string
+	for name := range sums {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			return err
+		}
+		if err := sums.check(dir, name, data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (sums Checksums) check(dir string, name string, data []byte) error {
+	want, found := sums[name]
+	if found && Checksum(data) != want {
+		return fmt.Errorf("%s has changed since it was cached", filepath.Join(dir, name))
+	}
+	return nil
+}
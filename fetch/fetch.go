@@ -0,0 +1,119 @@
+// Package fetch talks to the puzzle site. It downloads puzzle inputs and
+// keeps them in each day's directory, so that a day is only ever downloaded
+// once, and it submits answers, keeping a log of the guesses so that a
+// known-wrong answer is never sent twice.
+package fetch
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// DefaultURL is the site the puzzles come from.
+const DefaultURL = "https://adventofcode.com"
+
+// Year is the event these solutions are for.
+const Year = 2021
+
+const userAgent = "github.com/kentquirk/aoc2021/fetch"
+
+// Client downloads puzzle pages and inputs. Inputs are different for every
+// user, so the site needs the session token from a logged-in browser.
+type Client struct {
+	BaseURL string
+	Session string
+	HTTP    *http.Client // if nil, http.DefaultClient is used
+}
+
+// NewClient creates a client for the site at baseURL.
+func NewClient(baseURL string, session string) *Client {
+	return &Client{BaseURL: strings.TrimRight(baseURL, "/"), Session: session}
+}
+
+// DayURL returns the URL of a day's puzzle page.
+func (c *Client) DayURL(day int) string {
+	return fmt.Sprintf("%s/%d/day/%d", c.BaseURL, Year, day)
+}
+
+// do sends a request to the site; form, if not nil, is posted as the body
+func (c *Client) do(method string, addr string, form url.Values) ([]byte, error) {
+	var body io.Reader
+	if form != nil {
+		body = strings.NewReader(form.Encode())
+	}
+	req, err := http.NewRequest(method, addr, body)
+	if err != nil {
+		return nil, err
+	}
+	if form != nil {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+	req.Header.Set("User-Agent", userAgent)
+	if c.Session != "" {
+		req.AddCookie(&http.Cookie{Name: "session", Value: c.Session})
+	}
+	hc := c.HTTP
+	if hc == nil {
+		hc = http.DefaultClient
+	}
+	resp, err := hc.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	page, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return page, nil
+	case http.StatusNotFound:
+		return nil, fmt.Errorf("%s: not found (the puzzle may not be unlocked yet)", addr)
+	case http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusInternalServerError:
+		// this is how the site responds to a missing or expired session
+		return nil, fmt.Errorf("%s: %s (check the session token)", addr, resp.Status)
+	}
+	return nil, fmt.Errorf("%s: %s", addr, resp.Status)
+}
+
+// Input downloads a day's puzzle input.
+func (c *Client) Input(day int) ([]byte, error) {
+	return c.do(http.MethodGet, c.DayURL(day)+"/input", nil)
+}
+
+// Sample downloads a day's puzzle page and extracts the sample input from it.
+func (c *Client) Sample(day int) ([]byte, error) {
+	page, err := c.do(http.MethodGet, c.DayURL(day), nil)
+	if err != nil {
+		return nil, err
+	}
+	sample, err := Sample(page)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", c.DayURL(day), err)
+	}
+	return sample, nil
+}
+
+var (
+	codePat = regexp.MustCompile(`(?s)<pre><code>(.*?)</code></pre>`)
+	tagPat  = regexp.MustCompile(`<[^>]*>`)
+)
+
+// Sample extracts the sample input from a puzzle page. That's the first
+// preformatted block on the page, with any markup (like the <em> used for
+// emphasis) removed.
+func Sample(page []byte) ([]byte, error) {
+	m := codePat.FindSubmatch(page)
+	if m == nil {
+		return nil, fmt.Errorf("the puzzle page doesn't have a sample")
+	}
+	text := tagPat.ReplaceAllString(string(m[1]), "")
+	return []byte(html.UnescapeString(text)), nil
+}
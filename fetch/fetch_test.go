@@ -0,0 +1,170 @@
+package fetch_test
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/kentquirk/aoc2021/fetch"
+	"github.com/kentquirk/aoc2021/fetch/fetchtest"
+)
+
+func TestSample(t *testing.T) {
+	tests := []struct {
+		name    string
+		page    string
+		want    string
+		wantErr bool
+	}{
+		{"stand-in page", fetchtest.Page(1, fetchtest.Day{Sample: "199\n200\n"}), "199\n200\n", false},
+		{"markup and escapes", "<pre><code>a-&gt;<em>b</em>\n</code></pre>", "a->b\n", false},
+		{"no sample", "<p>nothing here</p>", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := fetch.Sample([]byte(tt.page))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Sample() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if string(got) != tt.want {
+				t.Errorf("Sample() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func newCache(t *testing.T, session string) (*fetch.Cache, *fetchtest.Server) {
+	srv := fetchtest.NewServer("secret")
+	t.Cleanup(srv.Close)
+	srv.AddDay(1, fetchtest.Day{Input: "1\n2\n3\n", Sample: "4\n5\n"})
+	return &fetch.Cache{Root: t.TempDir(), Client: fetch.NewClient(srv.URL, session)}, srv
+}
+
+func TestFetch(t *testing.T) {
+	cache, srv := newCache(t, "secret")
+
+	results, err := cache.Fetch(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range results {
+		if !r.Fetched {
+			t.Errorf("%s wasn't fetched", r.Path)
+		}
+	}
+	for name, want := range map[string]string{"input.txt": "1\n2\n3\n", "inputsample.txt": "4\n5\n"} {
+		got, err := os.ReadFile(filepath.Join(cache.Dir(1), name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != want {
+			t.Errorf("%s = %q, want %q", name, got, want)
+		}
+	}
+	want := []string{"/2021/day/1/input", "/2021/day/1"}
+	if got := srv.Requests(); !reflect.DeepEqual(got, want) {
+		t.Errorf("requests = %q, want %q", got, want)
+	}
+
+	// a cached day is never fetched again
+	results, err = cache.Fetch(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range results {
+		if r.Fetched {
+			t.Errorf("%s was fetched again", r.Path)
+		}
+	}
+	if got := srv.Requests(); len(got) != len(want) {
+		t.Errorf("requests = %q, want no more than %q", got, want)
+	}
+	if err := cache.Verify(1); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestFetchExisting(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing string // the contents of input.txt before fetching
+		fetched  bool
+	}{
+		{"placeholder", "", true},
+		{"committed by hand", "7\n8\n", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cache, _ := newCache(t, "secret")
+			path := filepath.Join(cache.Dir(1), "input.txt")
+			if err := os.MkdirAll(cache.Dir(1), 0755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(path, []byte(tt.existing), 0644); err != nil {
+				t.Fatal(err)
+			}
+			results, err := cache.Fetch(1)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if results[0].Fetched != tt.fetched {
+				t.Errorf("Fetched = %v, want %v", results[0].Fetched, tt.fetched)
+			}
+			sums, err := fetch.LoadChecksums(cache.Dir(1))
+			if err != nil {
+				t.Fatal(err)
+			}
+			data, _ := os.ReadFile(path)
+			if sums["input.txt"] != fetch.Checksum(data) {
+				t.Errorf("checksum = %q, want %q", sums["input.txt"], fetch.Checksum(data))
+			}
+		})
+	}
+}
+
+func TestFetchChanged(t *testing.T) {
+	cache, _ := newCache(t, "secret")
+	if _, err := cache.Fetch(1); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(cache.Dir(1), "input.txt")
+	if err := os.WriteFile(path, []byte("edited\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	for name, err := range map[string]error{"Verify": cache.Verify(1), "Fetch": fetchErr(cache)} {
+		if err == nil || !strings.Contains(err.Error(), "changed") {
+			t.Errorf("%s() error = %v, want one saying the file changed", name, err)
+		}
+	}
+}
+
+func fetchErr(cache *fetch.Cache) error {
+	_, err := cache.Fetch(1)
+	return err
+}
+
+func TestFetchErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		session string
+		day     int
+		want    string
+	}{
+		{"bad session", "wrong", 1, "session"},
+		{"not unlocked", "secret", 2, "not found"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cache, _ := newCache(t, tt.session)
+			_, err := cache.Fetch(tt.day)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Fetch() error = %v, want one mentioning %q", err, tt.want)
+			}
+			if _, err := os.Stat(filepath.Join(cache.Dir(tt.day), "input.txt")); err == nil {
+				t.Errorf("Fetch() wrote an input it couldn't download")
+			}
+		})
+	}
+}
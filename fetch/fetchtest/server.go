@@ -0,0 +1,175 @@
+// Package fetchtest provides a local stand-in for the puzzle site, so that
+// the code that talks to it can be tested without the real thing.
+package fetchtest
+
+import (
+	"fmt"
+	"html"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kentquirk/aoc2021/fetch"
+)
+
+// Server serves puzzle pages and inputs for the days that have been added
+// to it, and judges the answers submitted for them. Like the real site, it
+// only talks about inputs and answers to requests that have the right
+// session cookie, and after a wrong answer it won't take another one until
+// Cooldown has passed.
+type Server struct {
+	*httptest.Server
+	Session  string
+	Cooldown time.Duration
+
+	mu        sync.Mutex
+	days      map[int]Day
+	solved    map[string]bool
+	lastWrong time.Time
+	requests  []string
+}
+
+// Day is what the server knows about one day's puzzle. A and B are the
+// right answers for the two parts.
+type Day struct {
+	Input  string
+	Sample string
+	A      string
+	B      string
+}
+
+// NewServer starts a server that accepts the given session token. Call
+// Close when done with it.
+func NewServer(session string) *Server {
+	s := &Server{Session: session, days: make(map[int]Day), solved: make(map[string]bool)}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serve))
+	return s
+}
+
+// AddDay makes a day's puzzle available.
+func (s *Server) AddDay(day int, d Day) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.days[day] = d
+}
+
+// Requests returns the paths of the requests the server has received.
+func (s *Server) Requests() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.requests...)
+}
+
+// Page returns the puzzle page for a day, with the sample marked up the way
+// the real site does it.
+func Page(day int, d Day) string {
+	return fmt.Sprintf(`<!DOCTYPE html>
+<html><body><main><article class="day-desc">
+<h2>--- Day %d: Test ---</h2>
+<p>For example:</p>
+<pre><code>%s</code></pre>
+<p>Another block that isn't the sample:</p>
+<pre><code><em>42</em></code></pre>
+</article></main></body></html>
+`, day, html.EscapeString(d.Sample))
+}
+
+func (s *Server) serve(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	s.requests = append(s.requests, r.URL.Path)
+	s.mu.Unlock()
+
+	prefix := fmt.Sprintf("/%d/day/", fetch.Year)
+	if !strings.HasPrefix(r.URL.Path, prefix) {
+		http.NotFound(w, r)
+		return
+	}
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, prefix), "/")
+	day, err := strconv.Atoi(parts[0])
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	s.mu.Lock()
+	d, found := s.days[day]
+	s.mu.Unlock()
+	if !found {
+		http.NotFound(w, r)
+		return
+	}
+
+	switch {
+	case len(parts) == 1 && r.Method == http.MethodGet:
+		fmt.Fprint(w, Page(day, d))
+	case len(parts) == 2 && parts[1] == "input" && r.Method == http.MethodGet:
+		if !s.loggedIn(w, r) {
+			return
+		}
+		fmt.Fprint(w, d.Input)
+	case len(parts) == 2 && parts[1] == "answer" && r.Method == http.MethodPost:
+		if !s.loggedIn(w, r) {
+			return
+		}
+		fmt.Fprintf(w, "<html><body><main>\n<article><p>%s</p></article>\n</main></body></html>\n",
+			s.judge(day, d, r.FormValue("level"), r.FormValue("answer")))
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (s *Server) loggedIn(w http.ResponseWriter, r *http.Request) bool {
+	if c, err := r.Cookie("session"); err != nil || c.Value != s.Session {
+		// the real site says this with a 400
+		http.Error(w, "Puzzle inputs differ by user.  Please log in to get your puzzle input.", http.StatusBadRequest)
+		return false
+	}
+	return true
+}
+
+// judge returns the message the real site gives for an answer
+func (s *Server) judge(day int, d Day, level string, answer string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	back := fmt.Sprintf(`<a href="/%d/day/%d">[Return to Day %d]</a>`, fetch.Year, day, day)
+	if left := time.Until(s.lastWrong.Add(s.Cooldown)); left > 0 {
+		left = left.Round(time.Second)
+		wait := fmt.Sprintf("%ds", int(left.Seconds())%60)
+		if left >= time.Minute {
+			wait = fmt.Sprintf("%dm %s", int(left.Minutes()), wait)
+		}
+		return fmt.Sprintf("You gave an answer too recently; you have to wait after submitting an answer before trying again.  You have %s left to wait. %s", wait, back)
+	}
+
+	var want string
+	switch level {
+	case "1":
+		want = d.A
+	case "2":
+		want = d.B
+	}
+	key := fmt.Sprintf("%d/%s", day, level)
+	if want == "" || s.solved[key] {
+		return "You don't seem to be solving the right level.  Did you already complete it? " + back
+	}
+	if answer == want {
+		s.solved[key] = true
+		return "That's the right answer!  You are one gold star closer to saving your vacation. " + back
+	}
+
+	s.lastWrong = time.Now()
+	hint := ""
+	a, aerr := strconv.Atoi(answer)
+	w, werr := strconv.Atoi(want)
+	switch {
+	case aerr != nil || werr != nil:
+	case a > w:
+		hint = "  your answer is too high."
+	case a < w:
+		hint = "  your answer is too low."
+	}
+	return fmt.Sprintf("That's not the right answer;%s  If you're stuck, make sure you're using the full input data.  Please wait one minute before trying again. %s", hint, back)
+}
@@ -0,0 +1,147 @@
+package fetch
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// GuessFile is the name of the file in each day's directory that records
+// the answers that have been submitted for it.
+const GuessFile = "guesses.json"
+
+// Guess is one submitted answer and what the site said about it.
+type Guess struct {
+	Part    string        `json:"part"`
+	Answer  string        `json:"answer"`
+	Verdict Verdict       `json:"verdict"`
+	Time    time.Time     `json:"time"`
+	Wait    time.Duration `json:"wait,omitempty"`
+}
+
+// Guesses is the log of the answers submitted for a day, oldest first.
+type Guesses []Guess
+
+// LoadGuesses reads the log of guesses from a day's directory. A directory
+// without one has no guesses.
+func LoadGuesses(dir string) (Guesses, error) {
+	data, err := os.ReadFile(filepath.Join(dir, GuessFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var g Guesses
+	if err := json.Unmarshal(data, &g); err != nil {
+		return nil, fmt.Errorf("%s: %v", filepath.Join(dir, GuessFile), err)
+	}
+	return g, nil
+}
+
+// Save writes the log of guesses to a day's directory.
+func (g Guesses) Save(dir string) error {
+	data, err := json.MarshalIndent(g, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, GuessFile), append(data, '\n'), 0644)
+}
+
+// Check says why an answer shouldn't be submitted at time now, based on
+// what the site has already said: the part has been solved, the answer is
+// known to be wrong or is outside the bounds set by earlier "too high" and
+// "too low" answers, or the site asked us to wait and it hasn't been long
+// enough. It returns nil if the answer is worth submitting.
+func (g Guesses) Check(part string, answer string, now time.Time) error {
+	part = strings.ToLower(part)
+	var low, high *big.Int
+	n, isNumber := new(big.Int).SetString(answer, 10)
+	for _, guess := range g {
+		if guess.Part != part {
+			continue
+		}
+		switch {
+		case guess.Verdict == Right:
+			if guess.Answer == answer {
+				return fmt.Errorf("%s is already known to be right", answer)
+			}
+			return fmt.Errorf("part %s is already solved: the answer was %s", part, guess.Answer)
+		case guess.Verdict == Solved:
+			return fmt.Errorf("the site says part %s is already solved", part)
+		case guess.Verdict.IsWrong() && guess.Answer == answer:
+			return fmt.Errorf("%s was already submitted and was %s", answer, guess.Verdict)
+		}
+		bound, ok := new(big.Int).SetString(guess.Answer, 10)
+		if !ok {
+			continue
+		}
+		switch guess.Verdict {
+		case TooLow:
+			if low == nil || bound.Cmp(low) > 0 {
+				low = bound
+			}
+		case TooHigh:
+			if high == nil || bound.Cmp(high) < 0 {
+				high = bound
+			}
+		}
+	}
+
+	switch {
+	case low == nil && high == nil:
+	case !isNumber:
+		return fmt.Errorf("%s isn't a number, but earlier answers were too high or too low", answer)
+	case low != nil && n.Cmp(low) <= 0:
+		return fmt.Errorf("%s is out of bounds: %s was already too low", answer, low)
+	case high != nil && n.Cmp(high) >= 0:
+		return fmt.Errorf("%s is out of bounds: %s was already too high", answer, high)
+	}
+
+	if until := g.WaitUntil(); now.Before(until) {
+		return fmt.Errorf("the site asked us to wait until %s (%s from now)",
+			until.Format(time.Kitchen), until.Sub(now).Round(time.Second))
+	}
+	return nil
+}
+
+// WaitUntil returns the time the site last asked us to wait until.
+func (g Guesses) WaitUntil() time.Time {
+	var until time.Time
+	for _, guess := range g {
+		if t := guess.Time.Add(guess.Wait); t.After(until) {
+			until = t
+		}
+	}
+	return until
+}
+
+// SubmitLogged checks an answer against the log, and if it's worth submitting,
+// sends it to the site and records the result in the day's directory.
+func (c *Client) SubmitLogged(dir string, day int, part string, answer string, now time.Time) (Response, error) {
+	guesses, err := LoadGuesses(dir)
+	if err != nil {
+		return Response{}, err
+	}
+	if err := guesses.Check(part, answer, now); err != nil {
+		return Response{}, fmt.Errorf("not submitting: %v", err)
+	}
+	resp, err := c.Submit(day, part, answer)
+	if err != nil {
+		return resp, err
+	}
+	guesses = append(guesses, Guess{
+		Part:    strings.ToLower(part),
+		Answer:  answer,
+		Verdict: resp.Verdict,
+		Time:    now,
+		Wait:    resp.Wait,
+	})
+	return resp, guesses.Save(dir)
+}
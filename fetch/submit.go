@@ -0,0 +1,115 @@
+package fetch
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Verdict is what the site said about a submitted answer.
+type Verdict string
+
+// The verdicts the site gives.
+const (
+	Right       Verdict = "right"
+	Wrong       Verdict = "wrong"
+	TooHigh     Verdict = "too high"
+	TooLow      Verdict = "too low"
+	RateLimited Verdict = "rate limited"
+	Solved      Verdict = "already solved"
+)
+
+// Response is the site's reply to a submitted answer. Wait is how long
+// the site wants us to wait before submitting again, if it said.
+type Response struct {
+	Verdict Verdict
+	Wait    time.Duration
+	Message string
+}
+
+// IsWrong says whether the verdict rules out the answer.
+func (v Verdict) IsWrong() bool {
+	return v == Wrong || v == TooHigh || v == TooLow
+}
+
+var (
+	articlePat = regexp.MustCompile(`(?s)<article>(.*?)</article>`)
+	leftPat    = regexp.MustCompile(`You have (?:(\d+)m )?(\d+)s left to wait`)
+	minutesPat = regexp.MustCompile(`wait (one|\d+) minutes? before trying again`)
+)
+
+// ParseResponse interprets the page the site returns for a submitted answer.
+func ParseResponse(page []byte) (Response, error) {
+	text := string(page)
+	if m := articlePat.FindStringSubmatch(text); m != nil {
+		text = m[1]
+	}
+	text = strings.Join(strings.Fields(tagPat.ReplaceAllString(text, "")), " ")
+	r := Response{Message: text}
+
+	switch {
+	case strings.Contains(text, "That's the right answer"):
+		r.Verdict = Right
+	case strings.Contains(text, "You gave an answer too recently"):
+		r.Verdict = RateLimited
+		if m := leftPat.FindStringSubmatch(text); m != nil {
+			mins, _ := strconv.Atoi(m[1])
+			secs, _ := strconv.Atoi(m[2])
+			r.Wait = time.Duration(mins)*time.Minute + time.Duration(secs)*time.Second
+		}
+		return r, nil
+	case strings.Contains(text, "That's not the right answer"):
+		switch {
+		case strings.Contains(text, "your answer is too high"):
+			r.Verdict = TooHigh
+		case strings.Contains(text, "your answer is too low"):
+			r.Verdict = TooLow
+		default:
+			r.Verdict = Wrong
+		}
+	case strings.Contains(text, "You don't seem to be solving the right level"):
+		r.Verdict = Solved
+		return r, nil
+	default:
+		return r, fmt.Errorf("can't make sense of the response: %q", text)
+	}
+
+	// wrong answers come with a wait of their own
+	if m := minutesPat.FindStringSubmatch(text); m != nil {
+		mins := 1
+		if m[1] != "one" {
+			mins, _ = strconv.Atoi(m[1])
+		}
+		r.Wait = time.Duration(mins) * time.Minute
+	}
+	return r, nil
+}
+
+// level converts a part name to the level the site uses for it
+func level(part string) (string, error) {
+	switch part {
+	case "a", "A":
+		return "1", nil
+	case "b", "B":
+		return "2", nil
+	}
+	return "", fmt.Errorf("part must be 'a' or 'b', not %q", part)
+}
+
+// Submit sends an answer for one part of a day's puzzle.
+func (c *Client) Submit(day int, part string, answer string) (Response, error) {
+	lvl, err := level(part)
+	if err != nil {
+		return Response{}, err
+	}
+	form := url.Values{"level": {lvl}, "answer": {answer}}
+	page, err := c.do(http.MethodPost, c.DayURL(day)+"/answer", form)
+	if err != nil {
+		return Response{}, err
+	}
+	return ParseResponse(page)
+}
@@ -0,0 +1,151 @@
+package fetch_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kentquirk/aoc2021/fetch"
+	"github.com/kentquirk/aoc2021/fetch/fetchtest"
+)
+
+func TestParseResponse(t *testing.T) {
+	page := func(msg string) string {
+		return "<html><body><main>\n<article><p>" + msg + "</p></article>\n</main></body></html>"
+	}
+	tests := []struct {
+		name    string
+		page    string
+		verdict fetch.Verdict
+		wait    time.Duration
+		wantErr bool
+	}{
+		{"right", page("That's the right answer!  You are one gold star closer."), fetch.Right, 0, false},
+		{"too high", page("That's not the right answer; your answer is too high.  Please wait one minute before trying again."), fetch.TooHigh, time.Minute, false},
+		{"too low", page("That's not the right answer; your answer is too low.  Please wait 5 minutes before trying again."), fetch.TooLow, 5 * time.Minute, false},
+		{"wrong", page("That's not the right answer.  If you're stuck, make sure you're using the full input data."), fetch.Wrong, 0, false},
+		{"rate limited", page("You gave an answer too recently; you have to wait.  You have 1m 12s left to wait."), fetch.RateLimited, 72 * time.Second, false},
+		{"rate limited, seconds", page("You gave an answer too recently.  You have 9s left to wait."), fetch.RateLimited, 9 * time.Second, false},
+		{"solved", page("You don't seem to be solving the right level.  Did you already complete it?"), fetch.Solved, 0, false},
+		{"nonsense", page("Welcome!"), "", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := fetch.ParseResponse([]byte(tt.page))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseResponse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if r.Verdict != tt.verdict || r.Wait != tt.wait {
+				t.Errorf("ParseResponse() = %q, %v, want %q, %v", r.Verdict, r.Wait, tt.verdict, tt.wait)
+			}
+		})
+	}
+}
+
+func TestCheck(t *testing.T) {
+	start := time.Date(2021, 12, 1, 5, 0, 0, 0, time.UTC)
+	guesses := fetch.Guesses{
+		{Part: "a", Answer: "100", Verdict: fetch.TooLow, Time: start},
+		{Part: "a", Answer: "500", Verdict: fetch.TooHigh, Time: start},
+		{Part: "a", Answer: "300", Verdict: fetch.Wrong, Time: start, Wait: time.Minute},
+		{Part: "b", Answer: "42", Verdict: fetch.Right, Time: start},
+	}
+	later := start.Add(time.Hour)
+	tests := []struct {
+		name   string
+		part   string
+		answer string
+		now    time.Time
+		want   string // part of the reason for refusing, or empty
+	}{
+		{"worth a try", "a", "200", later, ""},
+		{"too soon", "a", "200", start.Add(30 * time.Second), "wait"},
+		{"known wrong", "a", "300", later, "already submitted"},
+		{"too low", "a", "100", later, "already submitted"},
+		{"below the bound", "a", "99", later, "too low"},
+		{"above the bound", "a", "600", later, "too high"},
+		{"not a number", "a", "abc", later, "isn't a number"},
+		{"solved", "b", "43", later, "already solved"},
+		{"already right", "B", "42", later, "known to be right"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := guesses.Check(tt.part, tt.answer, tt.now)
+			switch {
+			case tt.want == "" && err != nil:
+				t.Errorf("Check() = %v, want nil", err)
+			case tt.want != "" && (err == nil || !strings.Contains(err.Error(), tt.want)):
+				t.Errorf("Check() = %v, want one mentioning %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestSubmitLogged(t *testing.T) {
+	srv := fetchtest.NewServer("secret")
+	defer srv.Close()
+	srv.AddDay(1, fetchtest.Day{A: "7", B: "5"})
+	client := fetch.NewClient(srv.URL, "secret")
+	dir := t.TempDir()
+
+	now := time.Now()
+	steps := []struct {
+		answer  string
+		after   time.Duration // since the previous step
+		verdict fetch.Verdict
+		refused bool
+	}{
+		{"9", 0, fetch.TooHigh, false},
+		{"8", 10 * time.Second, "", true}, // too soon after a wrong answer
+		{"9", 2 * time.Minute, "", true},  // known wrong
+		{"10", 0, "", true},               // out of bounds
+		{"3", 0, fetch.TooLow, false},
+		{"7", 2 * time.Minute, fetch.Right, false},
+		{"7", 0, "", true}, // already solved
+	}
+	submitted := 0
+	for i, step := range steps {
+		now = now.Add(step.after)
+		r, err := client.SubmitLogged(dir, 1, "a", step.answer, now)
+		if step.refused {
+			if err == nil {
+				t.Errorf("step %d: submitted %s, want it refused", i, step.answer)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("step %d: %v", i, err)
+		}
+		submitted++
+		if r.Verdict != step.verdict {
+			t.Errorf("step %d: %s was %q, want %q", i, step.answer, r.Verdict, step.verdict)
+		}
+	}
+
+	guesses, err := fetch.LoadGuesses(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(guesses) != submitted || len(srv.Requests()) != submitted {
+		t.Errorf("logged %d guesses and sent %d, want %d", len(guesses), len(srv.Requests()), submitted)
+	}
+}
+
+func TestSubmitRateLimited(t *testing.T) {
+	srv := fetchtest.NewServer("secret")
+	defer srv.Close()
+	srv.Cooldown = time.Hour
+	srv.AddDay(1, fetchtest.Day{A: "7"})
+	client := fetch.NewClient(srv.URL, "secret")
+
+	if _, err := client.Submit(1, "a", "8"); err != nil {
+		t.Fatal(err)
+	}
+	r, err := client.Submit(1, "a", "7")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Verdict != fetch.RateLimited || r.Wait < 59*time.Minute {
+		t.Errorf("Submit() = %q, wait %v, want %q for about an hour", r.Verdict, r.Wait, fetch.RateLimited)
+	}
+}
@@ -0,0 +1,106 @@
+package geometry
+
+// Box is a rectangle with its edges on the axes, including both corners.
+type Box struct {
+	Min Point
+	Max Point
+}
+
+// NewBox creates the box with any two opposite corners.
+func NewBox(a Point, b Point) Box {
+	return Box{Min: a, Max: a}.Extend(b)
+}
+
+// BoundingBox returns the smallest box that contains all the points. It
+// returns false if there aren't any.
+func BoundingBox(pts []Point) (Box, bool) {
+	if len(pts) == 0 {
+		return Box{}, false
+	}
+	b := Box{Min: pts[0], Max: pts[0]}
+	for _, p := range pts[1:] {
+		b = b.Extend(p)
+	}
+	return b, true
+}
+
+// Extend returns the smallest box that contains b and p.
+func (b Box) Extend(p Point) Box {
+	if p.X < b.Min.X {
+		b.Min.X = p.X
+	}
+	if p.Y < b.Min.Y {
+		b.Min.Y = p.Y
+	}
+	if p.X > b.Max.X {
+		b.Max.X = p.X
+	}
+	if p.Y > b.Max.Y {
+		b.Max.Y = p.Y
+	}
+	return b
+}
+
+// Contains says whether p is inside the box or on its edge.
+func (b Box) Contains(p Point) bool {
+	return p.X >= b.Min.X && p.X <= b.Max.X && p.Y >= b.Min.Y && p.Y <= b.Max.Y
+}
+
+// Width returns the number of columns in the box.
+func (b Box) Width() int {
+	return b.Max.X - b.Min.X + 1
+}
+
+// Height returns the number of rows in the box.
+func (b Box) Height() int {
+	return b.Max.Y - b.Min.Y + 1
+}
+
+// Box3 is a cuboid with its faces on the axes, including both corners.
+type Box3 struct {
+	Min Point3
+	Max Point3
+}
+
+// BoundingBox3 returns the smallest box that contains all the points. It
+// returns false if there aren't any.
+func BoundingBox3(pts []Point3) (Box3, bool) {
+	if len(pts) == 0 {
+		return Box3{}, false
+	}
+	b := Box3{Min: pts[0], Max: pts[0]}
+	for _, p := range pts[1:] {
+		b = b.Extend(p)
+	}
+	return b, true
+}
+
+// Extend returns the smallest box that contains b and p.
+func (b Box3) Extend(p Point3) Box3 {
+	if p.X < b.Min.X {
+		b.Min.X = p.X
+	}
+	if p.Y < b.Min.Y {
+		b.Min.Y = p.Y
+	}
+	if p.Z < b.Min.Z {
+		b.Min.Z = p.Z
+	}
+	if p.X > b.Max.X {
+		b.Max.X = p.X
+	}
+	if p.Y > b.Max.Y {
+		b.Max.Y = p.Y
+	}
+	if p.Z > b.Max.Z {
+		b.Max.Z = p.Z
+	}
+	return b
+}
+
+// Contains says whether p is inside the box or on its surface.
+func (b Box3) Contains(p Point3) bool {
+	return p.X >= b.Min.X && p.X <= b.Max.X &&
+		p.Y >= b.Min.Y && p.Y <= b.Max.Y &&
+		p.Z >= b.Min.Z && p.Z <= b.Max.Z
+}
@@ -0,0 +1,145 @@
+package geometry
+
+import (
+	"testing"
+)
+
+func TestDistances(t *testing.T) {
+	tests := []struct {
+		name      string
+		p, q      Point3
+		manhattan int
+		chebyshev int
+		dist2     int
+	}{
+		{"same", Point3{X: 1, Y: 2, Z: 3}, Point3{X: 1, Y: 2, Z: 3}, 0, 0, 0},
+		{"one axis", Point3{}, Point3{X: -4}, 4, 4, 16},
+		{"scanners 2 and 3", Point3{X: 1105, Y: -1205, Z: 1229}, Point3{X: -92, Y: -2380, Z: -20}, 3621, 1249, 1197*1197 + 1175*1175 + 1249*1249},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.p.Manhattan(tt.q); got != tt.manhattan {
+				t.Errorf("Manhattan() = %d, want %d", got, tt.manhattan)
+			}
+			if got := tt.p.Chebyshev(tt.q); got != tt.chebyshev {
+				t.Errorf("Chebyshev() = %d, want %d", got, tt.chebyshev)
+			}
+			if got := tt.p.Dist2(tt.q); got != tt.dist2 {
+				t.Errorf("Dist2() = %d, want %d", got, tt.dist2)
+			}
+			// the 2D versions should agree when Z doesn't matter
+			p2, q2 := Point{X: tt.p.X, Y: tt.p.Y}, Point{X: tt.q.X, Y: tt.q.Y}
+			if tt.p.Z == tt.q.Z && p2.Manhattan(q2) != tt.manhattan {
+				t.Errorf("2D Manhattan() = %d, want %d", p2.Manhattan(q2), tt.manhattan)
+			}
+		})
+	}
+}
+
+func TestVectors(t *testing.T) {
+	p, q := Point{X: 1, Y: 5}, Point{X: 4, Y: 2}
+	if v := p.VectorTo(q); v != (Point{X: 3, Y: -3}) || p.Add(v) != q {
+		t.Errorf("VectorTo() = %v", v)
+	}
+	if s := p.VectorTo(q).Sign(); s != (Point{X: 1, Y: -1}) {
+		t.Errorf("Sign() = %v", s)
+	}
+	if s := (Point{X: 0, Y: -7}).Sign().Scale(7); s != (Point{X: 0, Y: -7}) {
+		t.Errorf("Sign().Scale() = %v", s)
+	}
+	if !p.Less(q) || q.Less(p) || p.Less(p) {
+		t.Errorf("Less() isn't ordering by X")
+	}
+}
+
+func TestBox(t *testing.T) {
+	b, ok := BoundingBox([]Point{{X: 3, Y: -1}, {X: -2, Y: 4}, {X: 0, Y: 0}})
+	if !ok || b != NewBox(Point{X: 3, Y: 4}, Point{X: -2, Y: -1}) {
+		t.Fatalf("BoundingBox() = %v, %v", b, ok)
+	}
+	if b.Width() != 6 || b.Height() != 6 {
+		t.Errorf("size = %dx%d, want 6x6", b.Width(), b.Height())
+	}
+	tests := []struct {
+		p    Point
+		want bool
+	}{
+		{Point{X: 3, Y: 4}, true},
+		{Point{X: -2, Y: -1}, true},
+		{Point{X: 4, Y: 0}, false},
+		{Point{X: 0, Y: -2}, false},
+	}
+	for _, tt := range tests {
+		if got := b.Contains(tt.p); got != tt.want {
+			t.Errorf("Contains(%v) = %v, want %v", tt.p, got, tt.want)
+		}
+	}
+	if _, ok := BoundingBox(nil); ok {
+		t.Errorf("BoundingBox() of nothing is ok")
+	}
+	b3, _ := BoundingBox3([]Point3{{X: 1, Y: 1, Z: 1}, {X: -1, Y: 2, Z: 0}})
+	if !b3.Contains(Point3{X: 0, Y: 1, Z: 1}) || b3.Contains(Point3{X: 0, Y: 1, Z: 2}) {
+		t.Errorf("Box3 %v has the wrong contents", b3)
+	}
+}
+
+func det(r Rotation) int {
+	return r[0][0]*(r[1][1]*r[2][2]-r[1][2]*r[2][1]) -
+		r[0][1]*(r[1][0]*r[2][2]-r[1][2]*r[2][0]) +
+		r[0][2]*(r[1][0]*r[2][1]-r[1][1]*r[2][0])
+}
+
+func TestRotations(t *testing.T) {
+	if len(Rotations) != 24 || Rotations[0] != Identity {
+		t.Fatalf("there are %d rotations, starting with %v", len(Rotations), Rotations[0])
+	}
+	seen := make(map[Rotation]bool)
+	images := make(map[Point3]bool)
+	p := Point3{X: 1, Y: 2, Z: 3}
+	for _, r := range Rotations {
+		seen[r] = true
+		images[r.Apply(p)] = true
+		if det(r) != 1 {
+			t.Errorf("%v isn't a rotation", r)
+		}
+		if r.Compose(r.Inverse()) != Identity {
+			t.Errorf("%v composed with its inverse isn't the identity", r)
+		}
+		// the group is closed
+		for _, s := range Rotations {
+			if !contains(Rotations, r.Compose(s)) {
+				t.Fatalf("%v composed with %v isn't a rotation", r, s)
+			}
+		}
+	}
+	if len(seen) != 24 || len(images) != 24 {
+		t.Errorf("%d distinct rotations move %v to %d places, want 24", len(seen), p, len(images))
+	}
+
+	// four quarter turns about any axis get back to the start
+	for _, turn := range []Rotation{TurnX, TurnY, TurnZ} {
+		r := Identity
+		for i := 0; i < 4; i++ {
+			r = turn.Compose(r)
+		}
+		if r != Identity {
+			t.Errorf("four turns of %v = %v", turn, r)
+		}
+	}
+	if got := TurnZ.Apply(Point3{X: 1}); got != (Point3{Y: 1}) {
+		t.Errorf("TurnZ moves X to %v, want Y", got)
+	}
+	// applying a composition is the same as applying one after the other
+	if got, want := TurnX.Compose(TurnY).Apply(p), TurnX.Apply(TurnY.Apply(p)); got != want {
+		t.Errorf("Compose() = %v, want %v", got, want)
+	}
+}
+
+func contains(rs []Rotation, r Rotation) bool {
+	for _, s := range rs {
+		if s == r {
+			return true
+		}
+	}
+	return false
+}
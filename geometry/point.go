@@ -0,0 +1,150 @@
+// Package geometry has integer points and vectors in two and three
+// dimensions, the distances between them, the boxes that bound them, and
+// the rotations of three-dimensional space that keep the axes lined up.
+//
+// A point and a vector are the same type; the difference is only in how
+// they're used.
+package geometry
+
+import "fmt"
+
+// Abs returns the absolute value of x.
+func Abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+// Sign returns -1, 0 or 1 according to the sign of x.
+func Sign(x int) int {
+	switch {
+	case x < 0:
+		return -1
+	case x > 0:
+		return 1
+	}
+	return 0
+}
+
+// Point is a point or vector in two dimensions.
+type Point struct {
+	X int
+	Y int
+}
+
+// Add returns p moved by the vector v.
+func (p Point) Add(v Point) Point {
+	return Point{X: p.X + v.X, Y: p.Y + v.Y}
+}
+
+// Sub returns the vector from q to p.
+func (p Point) Sub(q Point) Point {
+	return Point{X: p.X - q.X, Y: p.Y - q.Y}
+}
+
+// VectorTo returns the vector from p to q.
+func (p Point) VectorTo(q Point) Point {
+	return q.Sub(p)
+}
+
+// Scale returns the vector p multiplied by k.
+func (p Point) Scale(k int) Point {
+	return Point{X: p.X * k, Y: p.Y * k}
+}
+
+// Sign returns the vector with each component of p replaced by its sign,
+// which is the single step along a horizontal, vertical or 45° line.
+func (p Point) Sign() Point {
+	return Point{X: Sign(p.X), Y: Sign(p.Y)}
+}
+
+// Less orders points by X, then by Y.
+func (p Point) Less(q Point) bool {
+	return p.X < q.X || p.X == q.X && p.Y < q.Y
+}
+
+// Manhattan returns the taxicab distance between p and q.
+func (p Point) Manhattan(q Point) int {
+	return Abs(q.X-p.X) + Abs(q.Y-p.Y)
+}
+
+// Chebyshev returns the number of king's moves between p and q.
+func (p Point) Chebyshev(q Point) int {
+	dx, dy := Abs(q.X-p.X), Abs(q.Y-p.Y)
+	if dx > dy {
+		return dx
+	}
+	return dy
+}
+
+// Dist2 returns the square of the straight-line distance between p and q.
+func (p Point) Dist2(q Point) int {
+	d := q.Sub(p)
+	return d.X*d.X + d.Y*d.Y
+}
+
+func (p Point) String() string {
+	return fmt.Sprintf("(%d,%d)", p.X, p.Y)
+}
+
+// Point3 is a point or vector in three dimensions.
+type Point3 struct {
+	X int
+	Y int
+	Z int
+}
+
+// Add returns p moved by the vector v.
+func (p Point3) Add(v Point3) Point3 {
+	return Point3{X: p.X + v.X, Y: p.Y + v.Y, Z: p.Z + v.Z}
+}
+
+// Sub returns the vector from q to p.
+func (p Point3) Sub(q Point3) Point3 {
+	return Point3{X: p.X - q.X, Y: p.Y - q.Y, Z: p.Z - q.Z}
+}
+
+// VectorTo returns the vector from p to q.
+func (p Point3) VectorTo(q Point3) Point3 {
+	return q.Sub(p)
+}
+
+// Scale returns the vector p multiplied by k.
+func (p Point3) Scale(k int) Point3 {
+	return Point3{X: p.X * k, Y: p.Y * k, Z: p.Z * k}
+}
+
+// Less orders points by X, then Y, then Z.
+func (p Point3) Less(q Point3) bool {
+	return p.X < q.X ||
+		p.X == q.X && p.Y < q.Y ||
+		p.X == q.X && p.Y == q.Y && p.Z < q.Z
+}
+
+// Manhattan returns the taxicab distance between p and q.
+func (p Point3) Manhattan(q Point3) int {
+	return Abs(q.X-p.X) + Abs(q.Y-p.Y) + Abs(q.Z-p.Z)
+}
+
+// Chebyshev returns the largest difference between p and q along any axis.
+func (p Point3) Chebyshev(q Point3) int {
+	d := Abs(q.X - p.X)
+	if dy := Abs(q.Y - p.Y); dy > d {
+		d = dy
+	}
+	if dz := Abs(q.Z - p.Z); dz > d {
+		d = dz
+	}
+	return d
+}
+
+// Dist2 returns the square of the straight-line distance between p and q.
+func (p Point3) Dist2(q Point3) int {
+	d := q.Sub(p)
+	return d.X*d.X + d.Y*d.Y + d.Z*d.Z
+}
+
+func (p Point3) String() string {
+	return fmt.Sprintf("(%d,%d,%d)", p.X, p.Y, p.Z)
+}
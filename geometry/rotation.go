@@ -0,0 +1,73 @@
+package geometry
+
+// Rotation turns three-dimensional space about the origin so that the axes
+// still line up with the axes. It's stored as a matrix whose rows give the
+// new X, Y and Z in terms of the old ones; every entry is -1, 0 or 1.
+type Rotation [3][3]int
+
+// Identity is the rotation that leaves everything where it is.
+var Identity = Rotation{{1, 0, 0}, {0, 1, 0}, {0, 0, 1}}
+
+// Quarter turns about each axis, counterclockwise looking from the
+// positive end of the axis toward the origin.
+var (
+	TurnX = Rotation{{1, 0, 0}, {0, 0, -1}, {0, 1, 0}}
+	TurnY = Rotation{{0, 0, 1}, {0, 1, 0}, {-1, 0, 0}}
+	TurnZ = Rotation{{0, -1, 0}, {1, 0, 0}, {0, 0, 1}}
+)
+
+// Rotations is all 24 of the rotations, starting with Identity. These are
+// the ways a cube can be turned to sit in the same place: any of the six
+// faces can point up, in any of four directions. Mirror images, which
+// can't be reached by turning, are not included.
+var Rotations = generate(Identity, TurnX, TurnY)
+
+// generate finds every rotation that can be made by composing the
+// generators, in the order they're first reached
+func generate(start Rotation, generators ...Rotation) []Rotation {
+	seen := map[Rotation]bool{start: true}
+	result := []Rotation{start}
+	for i := 0; i < len(result); i++ {
+		for _, g := range generators {
+			r := g.Compose(result[i])
+			if !seen[r] {
+				seen[r] = true
+				result = append(result, r)
+			}
+		}
+	}
+	return result
+}
+
+// Apply rotates p.
+func (r Rotation) Apply(p Point3) Point3 {
+	return Point3{
+		X: r[0][0]*p.X + r[0][1]*p.Y + r[0][2]*p.Z,
+		Y: r[1][0]*p.X + r[1][1]*p.Y + r[1][2]*p.Z,
+		Z: r[2][0]*p.X + r[2][1]*p.Y + r[2][2]*p.Z,
+	}
+}
+
+// Compose returns the rotation that does s and then r.
+func (r Rotation) Compose(s Rotation) Rotation {
+	var result Rotation
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			for k := 0; k < 3; k++ {
+				result[i][j] += r[i][k] * s[k][j]
+			}
+		}
+	}
+	return result
+}
+
+// Inverse returns the rotation that undoes r.
+func (r Rotation) Inverse() Rotation {
+	var result Rotation
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			result[i][j] = r[j][i]
+		}
+	}
+	return result
+}
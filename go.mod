@@ -1,5 +1,5 @@
-module github.com/kentquirk/aoc2021/day08
+module github.com/kentquirk/aoc2021
 
-go 1.17
+go 1.18
 
 require github.com/kentquirk/stringset/v2 v2.0.1
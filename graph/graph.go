@@ -0,0 +1,123 @@
+// Package graph holds graphs of nodes joined by weighted edges, and the
+// usual ways of searching them. A node can be anything comparable: the cave
+// names of day 12 are strings, and the cells of a grid are Points.
+package graph
+
+import (
+	"fmt"
+	"io"
+)
+
+// Edge leads to a node, at some cost.
+type Edge[K comparable] struct {
+	To     K
+	Weight int
+}
+
+// Interface is all that the searches need from a graph: the edges leading
+// out of each node. Graph implements it, but so can anything that can work
+// out its edges on the fly, like a grid that's too big to spell out.
+type Interface[K comparable] interface {
+	Edges(from K) []Edge[K]
+}
+
+// Graph stores its edges as adjacency lists. Nodes and edges are kept in the
+// order they were added, so searches and output are repeatable.
+type Graph[K comparable] struct {
+	undirected bool
+	nodes      []K
+	edges      map[K][]Edge[K]
+}
+
+// New creates an empty directed graph.
+func New[K comparable]() *Graph[K] {
+	return &Graph[K]{edges: make(map[K][]Edge[K])}
+}
+
+// NewUndirected creates an empty graph in which every edge goes both ways.
+func NewUndirected[K comparable]() *Graph[K] {
+	g := New[K]()
+	g.undirected = true
+	return g
+}
+
+// AddNode adds k to the graph if it isn't already there.
+func (g *Graph[K]) AddNode(k K) {
+	if !g.Has(k) {
+		g.nodes = append(g.nodes, k)
+		g.edges[k] = nil
+	}
+}
+
+// AddEdge adds an edge from one node to another, adding the nodes if
+// they're new. In an undirected graph it also adds the edge back.
+func (g *Graph[K]) AddEdge(from K, to K, weight int) {
+	g.AddNode(from)
+	g.AddNode(to)
+	g.edges[from] = append(g.edges[from], Edge[K]{To: to, Weight: weight})
+	if g.undirected && from != to {
+		g.edges[to] = append(g.edges[to], Edge[K]{To: from, Weight: weight})
+	}
+}
+
+// Has says whether k is a node of the graph.
+func (g *Graph[K]) Has(k K) bool {
+	_, found := g.edges[k]
+	return found
+}
+
+// Nodes returns all the nodes, in the order they were added.
+func (g *Graph[K]) Nodes() []K {
+	return g.nodes
+}
+
+// Edges returns the edges leading out of a node.
+func (g *Graph[K]) Edges(from K) []Edge[K] {
+	return g.edges[from]
+}
+
+// Neighbors returns the nodes that can be reached from a node in one step.
+func (g *Graph[K]) Neighbors(from K) []K {
+	result := make([]K, 0, len(g.edges[from]))
+	for _, e := range g.edges[from] {
+		result = append(result, e.To)
+	}
+	return result
+}
+
+// WriteDOT writes the graph in the Graphviz DOT language, so that it can be
+// drawn with something like 'dot -Tsvg'. Nodes are named with fmt.Sprint;
+// edges are labeled with their weight unless it's 1.
+func (g *Graph[K]) WriteDOT(w io.Writer, name string) error {
+	kind, arrow := "digraph", "->"
+	if g.undirected {
+		kind, arrow = "graph", "--"
+	}
+	if _, err := fmt.Fprintf(w, "%s %q {\n", kind, name); err != nil {
+		return err
+	}
+	// each edge of an undirected graph is stored twice, but drawn once
+	order := make(map[K]int, len(g.nodes))
+	for i, n := range g.nodes {
+		order[n] = i
+	}
+	for _, from := range g.nodes {
+		if _, err := fmt.Fprintf(w, "\t%q;\n", fmt.Sprint(from)); err != nil {
+			return err
+		}
+		for _, e := range g.edges[from] {
+			if g.undirected && order[e.To] < order[from] {
+				continue
+			}
+			label := ""
+			if e.Weight != 1 {
+				label = fmt.Sprintf(" [label=%d]", e.Weight)
+			}
+			if _, err := fmt.Fprintf(w, "\t%q %s %q%s;\n", fmt.Sprint(from), arrow, fmt.Sprint(e.To), label); err != nil {
+				return err
+			}
+		}
+	}
+	_, err := fmt.Fprintln(w, "}")
+	return err
+}
@@ -0,0 +1,167 @@
+package graph
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/kentquirk/aoc2021/geometry"
+)
+
+// the small example from day 12
+func caves() *Graph[string] {
+	g := NewUndirected[string]()
+	for _, e := range []string{"start-A", "start-b", "A-c", "A-b", "b-d", "A-end", "b-end"} {
+		ends := strings.Split(e, "-")
+		g.AddEdge(ends[0], ends[1], 1)
+	}
+	return g
+}
+
+func TestTraversal(t *testing.T) {
+	g := caves()
+	var order []string
+	steps := make(map[string]int)
+	tree := BFS[string](g, "start", func(k string, n int) bool {
+		order = append(order, k)
+		steps[k] = n
+		return true
+	})
+	if want := []string{"start", "A", "b", "c", "end", "d"}; !reflect.DeepEqual(order, want) {
+		t.Errorf("BFS order = %v, want %v", order, want)
+	}
+	if steps["end"] != 2 || steps["d"] != 2 || steps["c"] != 2 {
+		t.Errorf("BFS steps = %v", steps)
+	}
+	if path, _ := tree.PathTo("d"); !reflect.DeepEqual(path, []string{"start", "b", "d"}) {
+		t.Errorf("BFS path to d = %v", path)
+	}
+
+	order = nil
+	DFS[string](g, "start", func(k string) bool {
+		order = append(order, k)
+		return k != "b"
+	})
+	if want := []string{"start", "A", "c", "b", "end"}; !reflect.DeepEqual(order, want) {
+		t.Errorf("DFS order = %v, want %v", order, want)
+	}
+}
+
+func TestDijkstra(t *testing.T) {
+	g := New[int]()
+	g.AddEdge(1, 2, 7)
+	g.AddEdge(1, 3, 9)
+	g.AddEdge(1, 6, 14)
+	g.AddEdge(2, 3, 10)
+	g.AddEdge(2, 4, 15)
+	g.AddEdge(3, 4, 11)
+	g.AddEdge(3, 6, 2)
+	g.AddEdge(6, 5, 9)
+	g.AddEdge(4, 5, 6)
+	g.AddNode(7)
+
+	tree := Dijkstra[int](g, 1)
+	want := map[int]int{1: 0, 2: 7, 3: 9, 4: 20, 5: 20, 6: 11}
+	if !reflect.DeepEqual(tree.Dist, want) {
+		t.Errorf("Dist = %v, want %v", tree.Dist, want)
+	}
+	if path, _ := tree.PathTo(5); !reflect.DeepEqual(path, []int{1, 3, 6, 5}) {
+		t.Errorf("path to 5 = %v", path)
+	}
+	if _, found := tree.PathTo(7); found {
+		t.Errorf("found a path to the unconnected node")
+	}
+	// edges only go one way
+	if _, _, found := AStar[int](g, 5, 1, Zero[int]); found {
+		t.Errorf("found a path against the edges")
+	}
+}
+
+// risky is a grid whose edges cost the digit of the cell they lead to
+type risky []string
+
+func (r risky) Edges(from geometry.Point) []Edge[geometry.Point] {
+	var edges []Edge[geometry.Point]
+	for _, d := range []geometry.Point{{X: 0, Y: -1}, {X: -1, Y: 0}, {X: 1, Y: 0}, {X: 0, Y: 1}} {
+		p := from.Add(d)
+		if p.Y >= 0 && p.Y < len(r) && p.X >= 0 && p.X < len(r[p.Y]) {
+			edges = append(edges, Edge[geometry.Point]{To: p, Weight: int(r[p.Y][p.X] - '0')})
+		}
+	}
+	return edges
+}
+
+func TestAStar(t *testing.T) {
+	cave := risky{
+		"1163751742",
+		"1381373672",
+		"2136511328",
+		"3694931569",
+		"7463417111",
+		"1319128137",
+		"1359912421",
+		"3125421639",
+		"1293138521",
+		"2311944581",
+	}
+	start, goal := geometry.Point{}, geometry.Point{X: 9, Y: 9}
+	tests := []struct {
+		name string
+		h    Heuristic[geometry.Point]
+	}{
+		{"zero", Zero[geometry.Point]},
+		{"manhattan", Manhattan},
+		{"chebyshev", Chebyshev},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path, cost, found := AStar[geometry.Point](cave, start, goal, tt.h)
+			if !found || cost != 40 {
+				t.Fatalf("AStar() = %d, %v, want 40", cost, found)
+			}
+			if path[0] != start || path[len(path)-1] != goal {
+				t.Errorf("path goes from %v to %v", path[0], path[len(path)-1])
+			}
+			sum := 0
+			for i := 1; i < len(path); i++ {
+				if path[i-1].Manhattan(path[i]) != 1 {
+					t.Errorf("path jumps from %v to %v", path[i-1], path[i])
+				}
+				sum += int(cave[path[i].Y][path[i].X] - '0')
+			}
+			if sum != cost {
+				t.Errorf("path costs %d, not %d", sum, cost)
+			}
+		})
+	}
+}
+
+func TestWriteDOT(t *testing.T) {
+	g := NewUndirected[string]()
+	g.AddEdge("start", "A", 1)
+	g.AddEdge("A", "end", 3)
+	d := New[int]()
+	d.AddEdge(1, 2, 1)
+	d.AddEdge(2, 1, 1)
+	tests := []struct {
+		name  string
+		write func(sb *strings.Builder) error
+		want  string
+	}{
+		{"undirected", func(sb *strings.Builder) error { return g.WriteDOT(sb, "caves") },
+			"graph \"caves\" {\n\t\"start\";\n\t\"start\" -- \"A\";\n\t\"A\";\n\t\"A\" -- \"end\" [label=3];\n\t\"end\";\n}\n"},
+		{"directed", func(sb *strings.Builder) error { return d.WriteDOT(sb, "ints") },
+			"digraph \"ints\" {\n\t\"1\";\n\t\"1\" -> \"2\";\n\t\"2\";\n\t\"2\" -> \"1\";\n}\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sb strings.Builder
+			if err := tt.write(&sb); err != nil {
+				t.Fatal(err)
+			}
+			if sb.String() != tt.want {
+				t.Errorf("WriteDOT() =\n%s\nwant\n%s", sb.String(), tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,182 @@
+package graph
+
+import (
+	"container/heap"
+
+	"github.com/kentquirk/aoc2021/geometry"
+)
+
+// Tree records what a search found: how far each node it reached is from
+// the start, and the node it was reached from.
+type Tree[K comparable] struct {
+	Start K
+	Dist  map[K]int
+	Prev  map[K]K
+}
+
+func newTree[K comparable](start K) *Tree[K] {
+	return &Tree[K]{
+		Start: start,
+		Dist:  map[K]int{start: 0},
+		Prev:  make(map[K]K),
+	}
+}
+
+// PathTo returns the nodes on the way from the start to goal, including
+// both ends. It returns false if the search never reached goal.
+func (t *Tree[K]) PathTo(goal K) ([]K, bool) {
+	if _, found := t.Dist[goal]; !found {
+		return nil, false
+	}
+	path := []K{goal}
+	for k := goal; k != t.Start; {
+		k = t.Prev[k]
+		path = append(path, k)
+	}
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path, true
+}
+
+// BFS searches breadth first from start, calling visit for each node as it's
+// reached along with the number of steps it took. The search stops early if
+// visit returns false. The distances in the result count steps, and ignore
+// the weights.
+func BFS[K comparable](g Interface[K], start K, visit func(k K, steps int) bool) *Tree[K] {
+	t := newTree(start)
+	queue := []K{start}
+	for len(queue) > 0 {
+		k := queue[0]
+		queue = queue[1:]
+		if !visit(k, t.Dist[k]) {
+			break
+		}
+		for _, e := range g.Edges(k) {
+			if _, found := t.Dist[e.To]; !found {
+				t.Dist[e.To] = t.Dist[k] + 1
+				t.Prev[e.To] = k
+				queue = append(queue, e.To)
+			}
+		}
+	}
+	return t
+}
+
+// DFS searches depth first from start, calling visit for each node before
+// any of the nodes beyond it. If visit returns false, the search doesn't go
+// any further from that node. The distances in the result count the steps
+// along the way the search happened to go, which aren't necessarily the
+// fewest.
+func DFS[K comparable](g Interface[K], start K, visit func(k K) bool) *Tree[K] {
+	t := newTree(start)
+	var explore func(k K)
+	explore = func(k K) {
+		if !visit(k) {
+			return
+		}
+		for _, e := range g.Edges(k) {
+			if _, found := t.Dist[e.To]; !found {
+				t.Dist[e.To] = t.Dist[k] + 1
+				t.Prev[e.To] = k
+				explore(e.To)
+			}
+		}
+	}
+	explore(start)
+	return t
+}
+
+// Heuristic estimates the cost of getting from a node to the goal. For A* to
+// find the cheapest path, it must never overestimate.
+type Heuristic[K comparable] func(from K, goal K) int
+
+// Zero is the heuristic that knows nothing, which makes A* into Dijkstra.
+func Zero[K comparable](from K, goal K) int {
+	return 0
+}
+
+// Manhattan is the heuristic for a grid where each step costs at least 1
+// and can only go across or down.
+func Manhattan(from geometry.Point, goal geometry.Point) int {
+	return from.Manhattan(goal)
+}
+
+// Chebyshev is the heuristic for a grid where each step costs at least 1
+// and can also go diagonally.
+func Chebyshev(from geometry.Point, goal geometry.Point) int {
+	return from.Chebyshev(goal)
+}
+
+// Dijkstra finds the cheapest way from start to every node it can reach.
+// Weights must not be negative.
+func Dijkstra[K comparable](g Interface[K], start K) *Tree[K] {
+	return search(g, start, nil, Zero[K])
+}
+
+// AStar finds the cheapest path from start to goal, guided by h, and returns
+// it along with its cost. It returns false if there's no way to the goal.
+// Weights must not be negative.
+func AStar[K comparable](g Interface[K], start K, goal K, h Heuristic[K]) ([]K, int, bool) {
+	t := search(g, start, &goal, h)
+	path, found := t.PathTo(goal)
+	if !found {
+		return nil, 0, false
+	}
+	return path, t.Dist[goal], true
+}
+
+// search is Dijkstra's algorithm, ordered by the cost so far plus h. It
+// stops when it gets to the goal, if there is one.
+func search[K comparable](g Interface[K], start K, goal *K, h Heuristic[K]) *Tree[K] {
+	t := newTree(start)
+	done := make(map[K]bool)
+	estimate := func(k K) int {
+		if goal == nil {
+			return 0
+		}
+		return h(k, *goal)
+	}
+	q := &queue[K]{{node: start, priority: estimate(start)}}
+	for q.Len() > 0 {
+		k := heap.Pop(q).(item[K]).node
+		// a node goes on the queue again whenever a cheaper way to it turns
+		// up; only the first time it comes off counts
+		if done[k] {
+			continue
+		}
+		done[k] = true
+		if goal != nil && k == *goal {
+			break
+		}
+		for _, e := range g.Edges(k) {
+			d := t.Dist[k] + e.Weight
+			if old, found := t.Dist[e.To]; found && old <= d {
+				continue
+			}
+			t.Dist[e.To] = d
+			t.Prev[e.To] = k
+			heap.Push(q, item[K]{node: e.To, priority: d + estimate(e.To)})
+		}
+	}
+	return t
+}
+
+type item[K comparable] struct {
+	node     K
+	priority int
+}
+
+// queue is a min-heap of items, for container/heap
+type queue[K comparable] []item[K]
+
+func (q queue[K]) Len() int            { return len(q) }
+func (q queue[K]) Less(i, j int) bool  { return q[i].priority < q[j].priority }
+func (q queue[K]) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *queue[K]) Push(x interface{}) { *q = append(*q, x.(item[K])) }
+func (q *queue[K]) Pop() interface{} {
+	old := *q
+	x := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return x
+}
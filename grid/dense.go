@@ -0,0 +1,60 @@
+package grid
+
+// Dense is a fixed-size grid with its top left cell at (0, 0).
+type Dense[T any] struct {
+	width  int
+	height int
+	cells  []T
+}
+
+// NewDense creates a grid of the given size, with every cell set to the
+// zero value.
+func NewDense[T any](width int, height int) *Dense[T] {
+	return &Dense[T]{width: width, height: height, cells: make([]T, width*height)}
+}
+
+// Width returns the number of columns.
+func (g *Dense[T]) Width() int {
+	return g.width
+}
+
+// Height returns the number of rows.
+func (g *Dense[T]) Height() int {
+	return g.height
+}
+
+func (g *Dense[T]) InBounds(p Point) bool {
+	return p.X >= 0 && p.X < g.width && p.Y >= 0 && p.Y < g.height
+}
+
+func (g *Dense[T]) Get(p Point) T {
+	if !g.InBounds(p) {
+		var zero T
+		return zero
+	}
+	return g.cells[p.Y*g.width+p.X]
+}
+
+func (g *Dense[T]) Set(p Point, v T) {
+	if !g.InBounds(p) {
+		panic("grid: point out of bounds")
+	}
+	g.cells[p.Y*g.width+p.X] = v
+}
+
+func (g *Dense[T]) Bounds() (Point, Point) {
+	return Point{}, Point{X: g.width - 1, Y: g.height - 1}
+}
+
+func (g *Dense[T]) Each(f func(p Point, v T)) {
+	for i, v := range g.cells {
+		f(Point{X: i % g.width, Y: i / g.width}, v)
+	}
+}
+
+// Clone returns a copy of the grid.
+func (g *Dense[T]) Clone() *Dense[T] {
+	c := NewDense[T](g.width, g.height)
+	copy(c.cells, g.cells)
+	return c
+}
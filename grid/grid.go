@@ -0,0 +1,69 @@
+// Package grid holds two-dimensional grids of cells, which lots of the
+// puzzles are built on. A Dense grid has a fixed size and a value for every
+// cell; a Sparse grid is unbounded, and stores only the cells that differ
+// from its background.
+package grid
+
+import (
+	"strings"
+
+	"github.com/kentquirk/aoc2021/geometry"
+)
+
+// Point is the location of a cell. X is the column and Y is the row, so Y
+// increases going down the page, the way the puzzles are printed.
+type Point = geometry.Point
+
+// Grid is what the dense and sparse grids have in common.
+type Grid[T any] interface {
+	// Get returns the value of a cell; cells that aren't in the grid have
+	// the zero value (for a Dense grid) or the background (for a Sparse one).
+	Get(p Point) T
+	// Set changes the value of a cell. Setting a cell of a Dense grid that's
+	// out of bounds panics, just like indexing a slice would.
+	Set(p Point, v T)
+	// InBounds says whether a point is in the grid.
+	InBounds(p Point) bool
+	// Bounds returns the smallest and largest corners of the cells in the
+	// grid, inclusive.
+	Bounds() (Point, Point)
+	// Each calls f for every cell in the grid. A Dense grid goes in reading
+	// order; a Sparse grid only visits the cells it stores, in no order.
+	Each(f func(p Point, v T))
+}
+
+// Neighborhood is a set of offsets from a cell to the cells around it.
+type Neighborhood []Point
+
+// The usual neighborhoods, in reading order.
+var (
+	// Orthogonal is the four cells that share an edge with a cell.
+	Orthogonal = Neighborhood{{X: 0, Y: -1}, {X: -1, Y: 0}, {X: 1, Y: 0}, {X: 0, Y: 1}}
+	// Adjacent is the eight cells that share an edge or a corner with a cell.
+	Adjacent = Neighborhood{{X: -1, Y: -1}, {X: 0, Y: -1}, {X: 1, Y: -1}, {X: -1, Y: 0}, {X: 1, Y: 0}, {X: -1, Y: 1}, {X: 0, Y: 1}, {X: 1, Y: 1}}
+)
+
+// Neighbors returns the points in the neighborhood of p that are in the grid.
+func Neighbors[T any](g Grid[T], p Point, n Neighborhood) []Point {
+	result := make([]Point, 0, len(n))
+	for _, d := range n {
+		if q := p.Add(d); g.InBounds(q) {
+			result = append(result, q)
+		}
+	}
+	return result
+}
+
+// Format draws the cells of a grid from its smallest corner to its largest,
+// one row per line, using cell to draw each value.
+func Format[T any](g Grid[T], cell func(v T) string) string {
+	var sb strings.Builder
+	lo, hi := g.Bounds()
+	for y := lo.Y; y <= hi.Y; y++ {
+		for x := lo.X; x <= hi.X; x++ {
+			sb.WriteString(cell(g.Get(Point{X: x, Y: y})))
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
@@ -0,0 +1,128 @@
+package grid
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func sortPoints(pts []Point) []Point {
+	sort.Slice(pts, func(i, j int) bool {
+		if pts[i].Y != pts[j].Y {
+			return pts[i].Y < pts[j].Y
+		}
+		return pts[i].X < pts[j].X
+	})
+	return pts
+}
+
+func TestNeighbors(t *testing.T) {
+	dense := NewDense[int](3, 2)
+	sparse := NewSparse(0)
+	tests := []struct {
+		name string
+		g    Grid[int]
+		p    Point
+		n    Neighborhood
+		want []Point
+	}{
+		{"corner, orthogonal", dense, Point{X: 0, Y: 0}, Orthogonal, []Point{{X: 1, Y: 0}, {X: 0, Y: 1}}},
+		{"corner, adjacent", dense, Point{X: 2, Y: 1}, Adjacent, []Point{{X: 1, Y: 0}, {X: 2, Y: 0}, {X: 1, Y: 1}}},
+		{"edge, orthogonal", dense, Point{X: 1, Y: 0}, Orthogonal, []Point{{X: 0, Y: 0}, {X: 2, Y: 0}, {X: 1, Y: 1}}},
+		{"outside", dense, Point{X: 5, Y: 5}, Adjacent, []Point{}},
+		{"sparse goes on forever", sparse, Point{X: 0, Y: 0}, Orthogonal,
+			[]Point{{X: 0, Y: -1}, {X: -1, Y: 0}, {X: 1, Y: 0}, {X: 0, Y: 1}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Neighbors(tt.g, tt.p, tt.n); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Neighbors() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		want    string
+		wantErr bool
+	}{
+		{"digits", "219\n398\n", "219\n398\n", false},
+		{"crlf", "21\r\n39\r\n", "21\n39\n", false},
+		{"not a digit", "21\n3x\n", "", true},
+		{"ragged", "219\n39\n", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := ParseDigits(tt.text)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseDigits() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && FormatDigits(g) != tt.want {
+				t.Errorf("FormatDigits() = %q, want %q", FormatDigits(g), tt.want)
+			}
+		})
+	}
+}
+
+func TestDense(t *testing.T) {
+	g, err := ParseDigits("12\n34\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := g.Clone()
+	c.Set(Point{X: 1, Y: 1}, 9)
+	if g.Get(Point{X: 1, Y: 1}) != 4 || c.Get(Point{X: 1, Y: 1}) != 9 {
+		t.Errorf("Clone() shares cells with the original")
+	}
+	if v := g.Get(Point{X: 2, Y: 0}); v != 0 {
+		t.Errorf("Get() out of bounds = %d, want 0", v)
+	}
+
+	var order []Point
+	g.Each(func(p Point, _ int) { order = append(order, p) })
+	want := []Point{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 0, Y: 1}, {X: 1, Y: 1}}
+	if !reflect.DeepEqual(order, want) {
+		t.Errorf("Each() order = %v, want %v", order, want)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Set() out of bounds didn't panic")
+		}
+	}()
+	g.Set(Point{X: -1, Y: 0}, 1)
+}
+
+func TestSparse(t *testing.T) {
+	pixels, err := ParsePixels("#..\n..#\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := ToSparse[bool](pixels, false)
+	if g.Len() != 2 {
+		t.Errorf("Len() = %d, want 2", g.Len())
+	}
+	g.Set(Point{X: -2, Y: 3}, true)
+	g.Set(Point{X: 0, Y: 0}, false)
+	lo, hi := g.Bounds()
+	if lo != (Point{X: -2, Y: 1}) || hi != (Point{X: 2, Y: 3}) {
+		t.Errorf("Bounds() = %v, %v", lo, hi)
+	}
+	var stored []Point
+	g.Each(func(p Point, _ bool) { stored = append(stored, p) })
+	if want := []Point{{X: 2, Y: 1}, {X: -2, Y: 3}}; !reflect.DeepEqual(sortPoints(stored), want) {
+		t.Errorf("Each() visited %v, want %v", stored, want)
+	}
+	if got, want := FormatPixels(g), "....#\n.....\n#....\n"; got != want {
+		t.Errorf("FormatPixels() = %q, want %q", got, want)
+	}
+
+	// an inverted image stores the pixels that are off
+	inv := ToSparse[bool](pixels, true)
+	if inv.Len() != 4 || !inv.Get(Point{X: 100, Y: 100}) {
+		t.Errorf("inverted Len() = %d, Get() far away = %v", inv.Len(), inv.Get(Point{X: 100, Y: 100}))
+	}
+}
@@ -0,0 +1,66 @@
+package grid
+
+import (
+	"strings"
+
+	"github.com/kentquirk/aoc2021/input"
+)
+
+// Parse reads a grid with one character per cell, using cell to convert
+// each character; cell returns false for a character it doesn't accept.
+// All the lines must be the same length.
+func Parse[T any](text string, what string, cell func(r byte) (T, bool)) (*Dense[T], error) {
+	lines := strings.Split(strings.TrimRight(text, "\r\n"), "\n")
+	for i := range lines {
+		lines[i] = strings.TrimRight(lines[i], "\r")
+	}
+	g := NewDense[T](len(lines[0]), len(lines))
+	for y, line := range lines {
+		if len(line) != g.width {
+			return nil, input.Errorf(y+1, 0, "", "expected %d characters, found %d", g.width, len(line))
+		}
+		for x := 0; x < len(line); x++ {
+			v, ok := cell(line[x])
+			if !ok {
+				return nil, input.Errorf(y+1, x+1, line[x:x+1], "not %s", what)
+			}
+			g.cells[y*g.width+x] = v
+		}
+	}
+	return g, nil
+}
+
+// Digit converts a digit character to its value.
+func Digit(r byte) (int, bool) {
+	return int(r - '0'), r >= '0' && r <= '9'
+}
+
+// Pixel converts '#' to true and '.' to false.
+func Pixel(r byte) (bool, bool) {
+	return r == '#', r == '#' || r == '.'
+}
+
+// ParseDigits reads a grid of single digits.
+func ParseDigits(text string) (*Dense[int], error) {
+	return Parse(text, "a digit", Digit)
+}
+
+// ParsePixels reads a grid of '#' and '.'.
+func ParsePixels(text string) (*Dense[bool], error) {
+	return Parse(text, "'#' or '.'", Pixel)
+}
+
+// FormatDigits draws a grid of single digits.
+func FormatDigits(g Grid[int]) string {
+	return Format(g, func(v int) string { return string(rune('0' + v)) })
+}
+
+// FormatPixels draws a grid of pixels with '#' and '.'.
+func FormatPixels(g Grid[bool]) string {
+	return Format(g, func(v bool) string {
+		if v {
+			return "#"
+		}
+		return "."
+	})
+}
@@ -0,0 +1,80 @@
+package grid
+
+// Sparse is an unbounded grid where every cell has the background value
+// except the ones that have been set to something else. Only those are
+// stored, so Len is the number of cells that aren't the background.
+type Sparse[T comparable] struct {
+	Background T
+	cells      map[Point]T
+}
+
+// NewSparse creates a grid with every cell set to background.
+func NewSparse[T comparable](background T) *Sparse[T] {
+	return &Sparse[T]{Background: background, cells: make(map[Point]T)}
+}
+
+// ToSparse copies the cells of another grid that differ from background.
+func ToSparse[T comparable](g Grid[T], background T) *Sparse[T] {
+	s := NewSparse(background)
+	g.Each(func(p Point, v T) {
+		s.Set(p, v)
+	})
+	return s
+}
+
+// InBounds is always true, since a sparse grid goes on forever.
+func (g *Sparse[T]) InBounds(p Point) bool {
+	return true
+}
+
+func (g *Sparse[T]) Get(p Point) T {
+	if v, found := g.cells[p]; found {
+		return v
+	}
+	return g.Background
+}
+
+func (g *Sparse[T]) Set(p Point, v T) {
+	if v == g.Background {
+		delete(g.cells, p)
+		return
+	}
+	g.cells[p] = v
+}
+
+// Bounds returns the corners of the cells that aren't the background. If
+// there aren't any, both corners are (0, 0).
+func (g *Sparse[T]) Bounds() (Point, Point) {
+	var lo, hi Point
+	first := true
+	for p := range g.cells {
+		if first {
+			lo, hi = p, p
+			first = false
+		}
+		if p.X < lo.X {
+			lo.X = p.X
+		}
+		if p.Y < lo.Y {
+			lo.Y = p.Y
+		}
+		if p.X > hi.X {
+			hi.X = p.X
+		}
+		if p.Y > hi.Y {
+			hi.Y = p.Y
+		}
+	}
+	return lo, hi
+}
+
+func (g *Sparse[T]) Each(f func(p Point, v T)) {
+	for p, v := range g.cells {
+		f(p, v)
+	}
+}
+
+// Len returns the number of cells that aren't the background.
+func (g *Sparse[T]) Len() int {
+	return len(g.cells)
+}
@@ -0,0 +1,96 @@
+package input
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ParseError describes a problem with the input at a particular place.
+// Line and Col both start at 1; a Col of 0 means the problem is with the
+// line as a whole.
+type ParseError struct {
+	File string
+	Line int
+	Col  int
+	Text string
+	Msg  string
+}
+
+func (e *ParseError) Error() string {
+	var sb strings.Builder
+	if e.File != "" {
+		sb.WriteString(e.File + ":")
+	}
+	sb.WriteString(fmt.Sprintf("%d:", e.Line))
+	if e.Col > 0 {
+		sb.WriteString(fmt.Sprintf("%d:", e.Col))
+	}
+	sb.WriteString(" " + e.Msg)
+	if e.Text != "" {
+		sb.WriteString(fmt.Sprintf(": %q", e.Text))
+	}
+	return sb.String()
+}
+
+// Errorf creates a ParseError for the given line and column. Most parsers
+// don't know the name of the file they're reading, so the File is filled in
+// afterward by Input.Wrap.
+func Errorf(line int, col int, text string, format string, args ...interface{}) *ParseError {
+	return &ParseError{
+		Line: line,
+		Col:  col,
+		Text: text,
+		Msg:  fmt.Sprintf(format, args...),
+	}
+}
+
+// Offset adjusts the line number of a ParseError that came from parsing a
+// piece of the input that begins at line start (like one of the Blocks).
+// Other errors are returned unchanged.
+func Offset(err error, start int) error {
+	var pe *ParseError
+	if errors.As(err, &pe) {
+		pe.Line += start - 1
+	}
+	return err
+}
+
+// Wrap fills in the file name of a ParseError that came from this input.
+// Other errors are returned unchanged.
+func (in *Input) Wrap(err error) error {
+	var pe *ParseError
+	if errors.As(err, &pe) && pe.File == "" {
+		pe.File = in.Name
+	}
+	return err
+}
+
+// Explain describes an error in a form meant for people. For a ParseError
+// from this input, that includes the offending line with the column marked.
+func (in *Input) Explain(err error) string {
+	var pe *ParseError
+	if !errors.As(err, &pe) || pe.File != in.Name {
+		return err.Error()
+	}
+	lines := in.Lines()
+	if pe.Line < 1 || pe.Line > len(lines) {
+		return err.Error()
+	}
+	var sb strings.Builder
+	sb.WriteString(err.Error() + "\n")
+	sb.WriteString("    " + lines[pe.Line-1] + "\n")
+	if pe.Col > 0 {
+		sb.WriteString("    " + strings.Repeat(" ", pe.Col-1) + "^\n")
+	}
+	return strings.TrimRight(sb.String(), "\n")
+}
+
+// BlockLine returns the line number on which block i of Blocks begins.
+func (in *Input) BlockLine(i int) int {
+	line := 1
+	for _, b := range in.Blocks()[:i] {
+		line += strings.Count(b, "\n") + 2
+	}
+	return line
+}
@@ -0,0 +1,41 @@
+package input
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseError(t *testing.T) {
+	in := New("input.txt", "1,2\n\n3 4\n5 x\n")
+	tests := []struct {
+		name    string
+		err     error
+		want    string
+		explain string
+	}{
+		{"line and column", Errorf(4, 3, "x", "bad number"),
+			`input.txt:4:3: bad number: "x"`,
+			"input.txt:4:3: bad number: \"x\"\n    5 x\n      ^"},
+		{"whole line", Errorf(1, 0, "", "too short"),
+			"input.txt:1: too short",
+			"input.txt:1: too short\n    1,2"},
+		{"offset into a block", Offset(Errorf(2, 1, "5", "bad"), in.BlockLine(1)),
+			`input.txt:4:1: bad: "5"`,
+			"input.txt:4:1: bad: \"5\"\n    5 x\n    ^"},
+		{"past the end", Errorf(9, 1, "", "missing"),
+			"input.txt:9:1: missing",
+			"input.txt:9:1: missing"},
+		{"not a parse error", errors.New("boom"), "boom", "boom"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := in.Wrap(tt.err)
+			if got := err.Error(); got != tt.want {
+				t.Errorf("Error() = %q, want %q", got, tt.want)
+			}
+			if got := in.Explain(err); got != tt.explain {
+				t.Errorf("Explain() = %q, want %q", got, tt.explain)
+			}
+		})
+	}
+}
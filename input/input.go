@@ -0,0 +1,147 @@
+// Package input loads puzzle input and splits it up in the ways that the
+// puzzles need: lines, blank-line-separated blocks, comma-separated integers,
+// and grids of digits.
+package input
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Input is the complete text of a puzzle input, along with the name of
+// the place it came from.
+type Input struct {
+	Name string
+	Text string
+}
+
+// New creates an Input from text. Trailing newlines are removed so that
+// splitting the input doesn't produce an empty last line.
+func New(name string, text string) *Input {
+	return &Input{
+		Name: name,
+		Text: strings.TrimRight(text, "\r\n"),
+	}
+}
+
+// Read reads all of r into a new Input.
+func Read(name string, r io.Reader) (*Input, error) {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	return New(name, string(b)), nil
+}
+
+// Load reads the file at path into a new Input. A path of "-" reads stdin.
+func Load(path string) (*Input, error) {
+	if path == "-" {
+		return Read("<stdin>", os.Stdin)
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return New(path, string(b)), nil
+}
+
+// Path finds the file for a named variant of the input in dir. The empty
+// variant is input.txt; otherwise the variant name can be either a suffix
+// or a prefix, so "sample" finds inputsample.txt and "lq" finds either
+// inputlq.txt or lqinput.txt.
+func Path(dir string, variant string) (string, error) {
+	candidates := []string{"input" + variant + ".txt"}
+	if variant != "" {
+		candidates = append(candidates, variant+"input.txt")
+	}
+	for _, c := range candidates {
+		p := filepath.Join(dir, c)
+		if _, err := os.Stat(p); err == nil {
+			return p, nil
+		}
+	}
+	if variant == "" {
+		return "", fmt.Errorf("no input.txt in %s", dir)
+	}
+	return "", fmt.Errorf("no input named %q in %s", variant, dir)
+}
+
+// Variant loads the named variant of the input from dir.
+func Variant(dir string, variant string) (*Input, error) {
+	p, err := Path(dir, variant)
+	if err != nil {
+		return nil, err
+	}
+	return Load(p)
+}
+
+func (in *Input) String() string {
+	return in.Text
+}
+
+// Lines splits the input into lines.
+func (in *Input) Lines() []string {
+	if in.Text == "" {
+		return nil
+	}
+	return strings.Split(in.Text, "\n")
+}
+
+// Blocks splits the input into groups of lines separated by a blank line.
+func (in *Input) Blocks() []string {
+	if in.Text == "" {
+		return nil
+	}
+	return strings.Split(in.Text, "\n\n")
+}
+
+// Ints parses input consisting of comma-separated integers.
+func (in *Input) Ints() ([]int, error) {
+	var result []int
+	offset := 0
+	for _, s := range strings.Split(in.Text, ",") {
+		n, err := strconv.Atoi(strings.TrimSpace(s))
+		if err != nil {
+			trimmed := len(s) - len(strings.TrimLeft(s, " \t\r\n"))
+			line, col := Position(in.Text, offset+trimmed)
+			pe := Errorf(line, col, strings.TrimSpace(s), "not an integer")
+			pe.File = in.Name
+			return nil, pe
+		}
+		result = append(result, n)
+		offset += len(s) + 1
+	}
+	return result, nil
+}
+
+// Digits parses input consisting of rows of single digits into a grid
+// indexed by [row][col].
+func (in *Input) Digits() ([][]int, error) {
+	var grid [][]int
+	for r, line := range in.Lines() {
+		row := make([]int, len(line))
+		for c := range line {
+			if line[c] < '0' || line[c] > '9' {
+				pe := Errorf(r+1, c+1, line[c:c+1], "not a digit")
+				pe.File = in.Name
+				return nil, pe
+			}
+			row[c] = int(line[c] - '0')
+		}
+		grid = append(grid, row)
+	}
+	return grid, nil
+}
+
+// Position converts a byte offset in text to a line and column, both
+// starting at 1.
+func Position(text string, offset int) (int, int) {
+	before := text[:offset]
+	line := strings.Count(before, "\n") + 1
+	col := offset - strings.LastIndex(before, "\n")
+	return line, col
+}
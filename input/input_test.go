@@ -0,0 +1,107 @@
+package input
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestLines(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{"empty", "", nil},
+		{"no trailing newline", "a\nb", []string{"a", "b"}},
+		{"trailing newline", "a\nb\n", []string{"a", "b"}},
+		{"crlf", "a\nb\r\n", []string{"a", "b"}},
+		{"several trailing newlines", "a\nb\n\n\n", []string{"a", "b"}},
+		{"interior blank line", "a\n\nb\n", []string{"a", "", "b"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := New("test", tt.text).Lines(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Lines() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBlocks(t *testing.T) {
+	got := New("test", "1,2\n\n3 4\n5 6\n\n7 8\n").Blocks()
+	want := []string{"1,2", "3 4\n5 6", "7 8"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Blocks() = %q, want %q", got, want)
+	}
+}
+
+func TestInts(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		want    []int
+		wantErr bool
+	}{
+		{"simple", "3,4,3,1,2", []int{3, 4, 3, 1, 2}, false},
+		{"trailing newline", "16,1,2,0\n", []int{16, 1, 2, 0}, false},
+		{"negative", "-1, 2", []int{-1, 2}, false},
+		{"bad", "1,x,3", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := New("test", tt.text).Ints()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Ints() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Ints() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDigits(t *testing.T) {
+	got, err := New("test", "219\n398\n").Digits()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := [][]int{{2, 1, 9}, {3, 9, 8}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Digits() = %v, want %v", got, want)
+	}
+	if _, err := New("test", "21\n3x\n").Digits(); err == nil {
+		t.Errorf("Digits() accepted a non-digit")
+	}
+}
+
+func TestPath(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"input.txt", "inputsample.txt", "lqinput.txt"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("x\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	tests := []struct {
+		variant string
+		want    string
+		wantErr bool
+	}{
+		{"", "input.txt", false},
+		{"sample", "inputsample.txt", false},
+		{"lq", "lqinput.txt", false},
+		{"toy", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.variant, func(t *testing.T) {
+			got, err := Path(dir, tt.variant)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Path() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && got != filepath.Join(dir, tt.want) {
+				t.Errorf("Path() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package registry
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/kentquirk/aoc2021/input"
+)
+
+// Solver computes the answer to one part of a day's puzzle from its input.
+// Problems with the input are reported as an *input.ParseError.
+type Solver func(in *input.Input) (interface{}, error)
+
+// Drawer draws a picture of a day's input to w, in the named format (like
+// "svg" or "png"). It returns an error for formats it doesn't know.
+type Drawer func(in *input.Input, format string, w io.Writer) error
+
+// Day holds the solvers for both parts of a single day's puzzle, and a way
+// to draw it if the day has one.
+type Day struct {
+	Number int
+	A      Solver
+	B      Solver
+	Draw   Drawer
+}
+
+// Part returns the solver for part "a" or "b".
+func (d *Day) Part(part string) (Solver, bool) {
+	switch part {
+	case "a", "A":
+		return d.A, d.A != nil
+	case "b", "B":
+		return d.B, d.B != nil
+	}
+	return nil, false
+}
+
+var days = make(map[int]*Day)
+
+// Register records the solvers for a day. It's intended to be called from
+// the init function of each day's package; registering the same day twice
+// is a programming error, so it panics.
+func Register(day int, a Solver, b Solver) {
+	if _, found := days[day]; found {
+		panic(fmt.Sprintf("day %d registered twice", day))
+	}
+	days[day] = &Day{Number: day, A: a, B: b}
+}
+
+// RegisterDrawer records how to draw a day that's already been registered.
+// Like Register, it panics if it's called twice for the same day.
+func RegisterDrawer(day int, draw Drawer) {
+	d, found := days[day]
+	if !found {
+		panic(fmt.Sprintf("day %d must be registered before its drawer", day))
+	}
+	if d.Draw != nil {
+		panic(fmt.Sprintf("day %d drawer registered twice", day))
+	}
+	d.Draw = draw
+}
+
+// Lookup returns the registered solvers for a day.
+func Lookup(day int) (*Day, bool) {
+	d, found := days[day]
+	return d, found
+}
+
+// Days returns the numbers of all registered days in ascending order.
+func Days() []int {
+	var result []int
+	for n := range days {
+		result = append(result, n)
+	}
+	sort.Ints(result)
+	return result
+}